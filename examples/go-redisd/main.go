@@ -23,6 +23,7 @@
 	OPTIONS
 	-v      : Enable verbose output.
 	-p      : Enable profiling.
+	-protected-mode : Refuse non-loopback clients without passwords (default true).
 
 	RETURN VALUE
 	  Return EXIT_SUCCESS or EXIT_FAILURE
@@ -31,24 +32,28 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	clog "github.com/cybergarage/go-logger/log"
 	"github.com/cybergarage/go-redis/examples/go-redisd/server"
 )
 
 const (
-	programName = " go-redisd"
+	programName     = " go-redisd"
+	shutdownTimeout = 10 * time.Second
 )
 
 func main() {
 	isDebugEnabled := flag.Bool("debug", false, "enable debugging log output")
 	isProfileEnabled := flag.Bool("profile", false, "enable profiling server")
+	isProtectedMode := flag.Bool("protected-mode", true, "refuse non-loopback clients without passwords")
 	flag.Parse()
 
 	logLevel := clog.LevelTrace
@@ -66,6 +71,7 @@ func main() {
 	}
 
 	server := server.NewServer()
+	server.SetProtectedMode(*isProtectedMode)
 
 	err := server.Start()
 	if err != nil {
@@ -98,7 +104,11 @@ func main() {
 			case syscall.SIGINT, syscall.SIGTERM:
 				clog.Infof("caught %s, stopping...", s.String())
 
-				err := server.Stop()
+				ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+				err := server.Shutdown(ctx)
+
+				cancel()
+
 				if err != nil {
 					clog.Errorf("%s couldn't be stopped (%s)", programName, err.Error())
 					os.Exit(1)
@@ -109,6 +119,13 @@ func main() {
 		}
 	}()
 
+	go func() {
+		<-server.Done()
+		clog.Infof("%s was shut down by SHUTDOWN command", programName)
+
+		exitCh <- 0
+	}()
+
 	code := <-exitCh
 
 	os.Exit(code)
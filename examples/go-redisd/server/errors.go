@@ -22,4 +22,5 @@ var ErrNotFound = errors.New("not found")
 
 const (
 	errorInvalidStoredDataType = "invalid stored data type (%T)"
+	errorInvalidCursor         = "invalid cursor (%d)"
 )
@@ -0,0 +1,164 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// activeExpireCycleInterval is the interval of the active expiration cycles as the default hz of Redis.
+	activeExpireCycleInterval = 100 * time.Millisecond
+	// activeExpireCycleTimeLimit is the maximum time of an active expiration cycle.
+	activeExpireCycleTimeLimit = activeExpireCycleInterval / 4
+	// activeExpireCycleKeysPerLoop is the number of sampled keys per database in a loop.
+	activeExpireCycleKeysPerLoop = 20
+	// activeExpireCycleAcceptableStale is the percentage of expired keys in samples to stop the loop.
+	activeExpireCycleAcceptableStale = 10
+)
+
+// ExpireStats represents the numbers of expired keys.
+type ExpireStats struct {
+	LazyExpiredKeys   int64
+	ActiveExpiredKeys int64
+}
+
+// ExpiredKeys returns the total number of expired keys.
+func (stats ExpireStats) ExpiredKeys() int64 {
+	return stats.LazyExpiredKeys + stats.ActiveExpiredKeys
+}
+
+// expireCycle represents an active expiration cycle which runs in a goroutine.
+type expireCycle struct {
+	mutex  *sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newExpireCycle() *expireCycle {
+	return &expireCycle{
+		mutex:  &sync.Mutex{},
+		cancel: nil,
+		done:   nil,
+	}
+}
+
+// start starts the cycle which calls the specified function at every interval unless the cycle is running.
+// The cycle also stops when the specified channel is closed.
+func (cycle *expireCycle) start(fn func(), stopCh <-chan struct{}) {
+	cycle.mutex.Lock()
+	defer cycle.mutex.Unlock()
+
+	if cycle.cancel != nil {
+		select {
+		case <-cycle.done:
+			// Restarts the cycle stopped by the channel.
+			cycle.cancel()
+		default:
+			return
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	cycle.cancel = cancel
+	cycle.done = done
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(activeExpireCycleInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stopCh:
+				return
+			case <-ticker.C:
+				fn()
+			}
+		}
+	}()
+}
+
+// stop stops the cycle and waits for the goroutine.
+func (cycle *expireCycle) stop() {
+	cycle.mutex.Lock()
+	defer cycle.mutex.Unlock()
+
+	if cycle.cancel == nil {
+		return
+	}
+
+	cycle.cancel()
+	<-cycle.done
+
+	cycle.cancel = nil
+	cycle.done = nil
+}
+
+// ActiveExpire runs an active expiration cycle as the adaptive algorithm of Redis.
+// The cycle samples keys with expirations of each database, and repeats while more than the acceptable stale keys are expired in the samples.
+func (server *Server) ActiveExpire() {
+	deadline := time.Now().Add(activeExpireCycleTimeLimit)
+
+	server.Databases.Range(func(_, value any) bool {
+		db, ok := value.(*Database)
+		if !ok {
+			return true
+		}
+
+		for {
+			sampled, expired := db.ActiveExpire(activeExpireCycleKeysPerLoop)
+			if sampled == 0 || expired*100 <= sampled*activeExpireCycleAcceptableStale {
+				break
+			}
+
+			if !time.Now().Before(deadline) {
+				return false
+			}
+		}
+
+		return time.Now().Before(deadline)
+	})
+}
+
+// ExpireStats returns the numbers of expired keys of all databases.
+func (server *Server) ExpireStats() ExpireStats {
+	stats := ExpireStats{
+		LazyExpiredKeys:   0,
+		ActiveExpiredKeys: 0,
+	}
+
+	server.Databases.Range(func(_, value any) bool {
+		db, ok := value.(*Database)
+		if !ok {
+			return true
+		}
+
+		dbStats := db.ExpireStats()
+		stats.LazyExpiredKeys += dbStats.LazyExpiredKeys
+		stats.ActiveExpiredKeys += dbStats.ActiveExpiredKeys
+
+		return true
+	})
+
+	return stats
+}
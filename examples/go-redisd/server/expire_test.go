@@ -0,0 +1,149 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestLazyExpire(t *testing.T) {
+	server := NewServer()
+
+	db, err := server.GetDatabase(0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	record := &Record{
+		Key:       "key",
+		Data:      "val",
+		Timestamp: time.Now().Add(-time.Second),
+		TTL:       time.Millisecond,
+	}
+
+	err = db.SetRecord(record)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if _, ok := db.GetRecord(record.Key); ok {
+		t.Errorf("%s is not expired", record.Key)
+	}
+
+	if _, ok := db.Load(record.Key); ok {
+		t.Errorf("%s is not removed", record.Key)
+	}
+
+	stats := server.ExpireStats()
+	if stats.LazyExpiredKeys != 1 || stats.ActiveExpiredKeys != 0 {
+		t.Errorf("%v != {1 0}", stats)
+	}
+}
+
+func TestActiveExpire(t *testing.T) {
+	const (
+		persistentKeys = 10
+		expiredKeys    = 100
+	)
+
+	server := NewServer()
+
+	db, err := server.GetDatabase(0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	now := time.Now()
+
+	for n := range persistentKeys + expiredKeys {
+		record := &Record{
+			Key:       strconv.Itoa(n),
+			Data:      "val",
+			Timestamp: now,
+			TTL:       0,
+		}
+
+		if persistentKeys <= n {
+			record.TTL = time.Millisecond
+		}
+
+		err := db.SetRecord(record)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	// All expired keys are removed because the samples contain only expired keys.
+	server.ActiveExpire()
+
+	if n := len(db.Keys()); n != persistentKeys {
+		t.Errorf("%d != %d", n, persistentKeys)
+	}
+
+	stats := server.ExpireStats()
+	if stats.ActiveExpiredKeys != expiredKeys || stats.LazyExpiredKeys != 0 {
+		t.Errorf("%v != {0 %d}", stats, expiredKeys)
+	}
+}
+
+func TestExpireCycle(t *testing.T) {
+	cycle := newExpireCycle()
+
+	isRunning := func() bool {
+		cycle.mutex.Lock()
+		defer cycle.mutex.Unlock()
+
+		if cycle.done == nil {
+			return false
+		}
+
+		select {
+		case <-cycle.done:
+			return false
+		default:
+			return true
+		}
+	}
+
+	// The cycle stops when the server is shut down, and restarts when the server is started again.
+	stopCh := make(chan struct{})
+	cycle.start(func() {}, stopCh)
+
+	close(stopCh)
+
+	for isRunning() {
+		time.Sleep(time.Millisecond)
+	}
+
+	cycle.start(func() {}, make(chan struct{}))
+
+	if !isRunning() {
+		t.Error("the cycle is not restarted")
+	}
+
+	cycle.stop()
+
+	if isRunning() {
+		t.Error("the cycle is not stopped")
+	}
+}
@@ -15,13 +15,10 @@
 package server
 
 import (
-	"sort"
-	"strconv"
 	"time"
 
 	"github.com/cybergarage/go-redis/redis"
 	"github.com/cybergarage/go-redis/redis/glob"
-	"github.com/cybergarage/go-redis/redis/proto"
 )
 
 func (server *Server) Del(conn *redis.Conn, keys []string) (*redis.Message, error) {
@@ -71,12 +68,56 @@ func (server *Server) Expire(conn *redis.Conn, key string, opt redis.ExpireOptio
 		return redis.NewIntegerMessage(0), nil
 	}
 
-	now := time.Now()
-	record.TTL = opt.Time.Sub(now)
+	// A key without expiration is treated as an infinite TTL for GT and LT.
+	currTime := record.ExpireTime()
+
+	switch {
+	case opt.NX && !currTime.IsZero():
+		return redis.NewIntegerMessage(0), nil
+	case opt.XX && currTime.IsZero():
+		return redis.NewIntegerMessage(0), nil
+	case opt.GT && (currTime.IsZero() || !opt.Time.After(currTime)):
+		return redis.NewIntegerMessage(0), nil
+	case opt.LT && !currTime.IsZero() && !opt.Time.Before(currTime):
+		return redis.NewIntegerMessage(0), nil
+	}
+
+	// A non-positive TTL deletes the key.
+	if !opt.Time.After(time.Now()) {
+		err = db.RemoveRecord(key)
+		if err != nil {
+			return nil, err
+		}
+
+		return redis.NewIntegerMessage(1), nil
+	}
+
+	if !db.SetExpireTime(record, opt.Time) {
+		return redis.NewIntegerMessage(0), nil
+	}
 
 	return redis.NewIntegerMessage(1), nil
 }
 
+func (server *Server) ExpireTime(conn *redis.Conn, key string) (redis.KeyExpiration, error) {
+	db, err := server.GetDatabase(conn.Database())
+	if err != nil {
+		return redis.NewKeyExpirationNotExist(), err
+	}
+
+	record, ok := db.GetRecord(key)
+	if !ok {
+		return redis.NewKeyExpirationNotExist(), nil
+	}
+
+	expireTime := record.ExpireTime()
+	if expireTime.IsZero() {
+		return redis.NewKeyExpirationNoExpire(), nil
+	}
+
+	return redis.NewKeyExpiration(expireTime), nil
+}
+
 func (server *Server) Type(conn *redis.Conn, key string) (*redis.Message, error) {
 	db, err := server.GetDatabase(conn.Database())
 	if err != nil {
@@ -153,71 +194,90 @@ func (server *Server) Rename(conn *redis.Conn, key string, newkey string, opt re
 }
 
 func (server *Server) TTL(conn *redis.Conn, key string) (*redis.Message, error) {
-	const (
-		ttlRecordNotFound int = -2
-		ttlRecordNotSet   int = -1
-	)
+	exp, err := server.ExpireTime(conn, key)
+	if err != nil {
+		return nil, err
+	}
 
-	db, err := server.GetDatabase(conn.Database())
+	return redis.NewIntegerMessage(exp.TTL(time.Now(), time.Second)), nil
+}
+
+func (server *Server) Copy(conn *redis.Conn, src string, dst string, opt redis.CopyOption) (*redis.Message, error) {
+	srcDB, err := server.GetDatabase(conn.Database())
 	if err != nil {
 		return nil, err
 	}
 
-	record, ok := db.GetRecord(key)
+	dstDB, err := server.GetDatabase(opt.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	record, ok := srcDB.GetRecord(src)
 	if !ok {
-		return redis.NewIntegerMessage(ttlRecordNotFound), nil
+		return redis.NewIntegerMessage(0), nil
 	}
 
-	if record.TTL <= 0 {
-		return redis.NewIntegerMessage(ttlRecordNotSet), nil
+	if !opt.REPLACE && dstDB.HasRecord(dst) {
+		return redis.NewIntegerMessage(0), nil
 	}
 
-	now := time.Now()
+	newRecord, err := record.CopyWithKey(dst)
+	if err != nil {
+		return nil, err
+	}
 
-	ttl := record.Timestamp.Add(record.TTL).Sub(now)
-	if ttl < 0 {
-		return redis.NewIntegerMessage(ttlRecordNotFound), nil
+	err = dstDB.SetRecord(newRecord)
+	if err != nil {
+		return nil, err
 	}
 
-	return redis.NewIntegerMessage(int(ttl / time.Second)), nil
+	return redis.NewIntegerMessage(1), nil
 }
 
-func (server *Server) Scan(conn *redis.Conn, cursor int, opt redis.ScanOption) (*redis.Message, error) {
-	db, err := server.GetDatabase(conn.Database())
+func (server *Server) Move(conn *redis.Conn, key string, id redis.DatabaseID) (*redis.Message, error) {
+	srcDB, err := server.GetDatabase(conn.Database())
 	if err != nil {
 		return nil, err
 	}
 
-	keys := db.Keys()
-	sort.Strings(keys)
+	dstDB, err := server.GetDatabase(id)
+	if err != nil {
+		return nil, err
+	}
 
-	matchKeys := proto.NewArray()
+	record, ok := srcDB.GetRecord(key)
+	if !ok || dstDB.HasRecord(key) {
+		return redis.NewIntegerMessage(0), nil
+	}
 
-	lastCursor := 0
-	for n, key := range keys {
-		lastCursor = n
-		if 0 < cursor && n <= cursor {
-			continue
-		}
+	err = dstDB.SetRecord(record)
+	if err != nil {
+		return nil, err
+	}
 
-		if !opt.MatchPattern.MatchString(key) {
-			continue
-		}
+	err = srcDB.RemoveRecord(key)
+	if err != nil {
+		return nil, err
+	}
 
-		matchKeys.Append(redis.NewBulkMessage(key))
+	return redis.NewIntegerMessage(1), nil
+}
 
-		if opt.Count <= matchKeys.Size() {
-			break
-		}
+func (server *Server) Persist(conn *redis.Conn, key string) (*redis.Message, error) {
+	db, err := server.GetDatabase(conn.Database())
+	if err != nil {
+		return nil, err
 	}
 
-	if lastCursor == len(keys) {
-		lastCursor = 0
+	record, ok := db.GetRecord(key)
+	if !ok || record.TTL <= 0 {
+		return redis.NewIntegerMessage(0), nil
 	}
 
-	array := proto.NewArray()
-	array.Append(redis.NewBulkMessage(strconv.Itoa(lastCursor)))
-	array.Append(redis.NewArrayMessageWithArray(matchKeys))
+	if !db.SetExpireTime(record, time.Time{}) {
+		return redis.NewIntegerMessage(0), nil
+	}
 
-	return proto.NewMessageWithType(proto.ArrayMessage).SetArray(array), nil
+	return redis.NewIntegerMessage(1), nil
 }
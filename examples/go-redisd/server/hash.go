@@ -15,6 +15,7 @@
 package server
 
 import (
+	"maps"
 	"time"
 
 	"github.com/cybergarage/go-redis/redis"
@@ -59,6 +60,11 @@ func (hash Hash) Del(fields []string) int {
 	return removedFields
 }
 
+// Copy returns a copy of the hash.
+func (hash Hash) Copy() Hash {
+	return maps.Clone(hash)
+}
+
 ////////////////////////////////////////////////////////////
 // Hash command handler
 ////////////////////////////////////////////////////////////
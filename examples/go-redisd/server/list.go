@@ -15,6 +15,10 @@
 package server
 
 import (
+	"fmt"
+	"slices"
+	"sync"
+
 	"github.com/cybergarage/go-redis/redis"
 )
 
@@ -22,17 +26,23 @@ import (
 // List
 ////////////////////////////////////////////////////////////
 
+// List represents a list value which is safe for concurrent use.
 type List struct {
+	mutex    *sync.Mutex
 	elements []string
 }
 
 func NewList() *List {
 	return &List{
+		mutex:    &sync.Mutex{},
 		elements: []string{},
 	}
 }
 
 func (list *List) LPop(count int) ([]string, bool) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
 	if count < 1 {
 		return nil, false
 	}
@@ -52,6 +62,9 @@ func (list *List) LPop(count int) ([]string, bool) {
 }
 
 func (list *List) LPush(elems []string) int {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
 	for _, elem := range elems {
 		list.elements = append([]string{elem}, list.elements...)
 	}
@@ -60,6 +73,9 @@ func (list *List) LPush(elems []string) int {
 }
 
 func (list *List) RPop(count int) ([]string, bool) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
 	if count < 1 {
 		return nil, false
 	}
@@ -79,11 +95,21 @@ func (list *List) RPop(count int) ([]string, bool) {
 }
 
 func (list *List) RPush(elems []string) int {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
 	list.elements = append(list.elements, elems...)
 	return len(list.elements)
 }
 
 func (list *List) Range(start int, stop int) []string {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	return list.rangeElements(start, stop)
+}
+
+func (list *List) rangeElements(start int, stop int) []string {
 	if start < 0 {
 		start = len(list.elements) + start
 	}
@@ -106,6 +132,9 @@ func (list *List) Range(start int, stop int) []string {
 }
 
 func (list *List) Index(idx int) (string, bool) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
 	if idx < 0 {
 		idx = len(list.elements) + idx
 	}
@@ -118,9 +147,104 @@ func (list *List) Index(idx int) (string, bool) {
 }
 
 func (list *List) Len() int {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
 	return len(list.elements)
 }
 
+// Set sets the element at the index, and returns false if the index is out of range.
+func (list *List) Set(idx int, elem string) bool {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	if idx < 0 {
+		idx = len(list.elements) + idx
+	}
+
+	if (idx < 0) || ((len(list.elements) - 1) < idx) {
+		return false
+	}
+
+	list.elements[idx] = elem
+
+	return true
+}
+
+// Insert inserts the element before or after the pivot, and returns the new length or -1 if the pivot is not found.
+func (list *List) Insert(pivot string, elem string, before bool) int {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	idx := slices.Index(list.elements, pivot)
+	if idx < 0 {
+		return -1
+	}
+
+	if !before {
+		idx++
+	}
+
+	list.elements = slices.Insert(list.elements, idx, elem)
+
+	return len(list.elements)
+}
+
+// Rem removes the count occurrences of the element from the head, from the tail if the count is negative, or all occurrences if the count is 0.
+func (list *List) Rem(count int, elem string) int {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	removed := 0
+
+	if count < 0 {
+		for n := len(list.elements) - 1; 0 <= n && removed < -count; n-- {
+			if list.elements[n] != elem {
+				continue
+			}
+
+			list.elements = slices.Delete(list.elements, n, n+1)
+			removed++
+		}
+
+		return removed
+	}
+
+	elems := []string{}
+
+	for _, e := range list.elements {
+		if e == elem && (count == 0 || removed < count) {
+			removed++
+			continue
+		}
+
+		elems = append(elems, e)
+	}
+
+	list.elements = elems
+
+	return removed
+}
+
+// Trim trims the list to the specified range.
+func (list *List) Trim(start int, stop int) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.elements = list.rangeElements(start, stop)
+}
+
+// Copy returns a copy of the list.
+func (list *List) Copy() *List {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	return &List{
+		mutex:    &sync.Mutex{},
+		elements: slices.Clone(list.elements),
+	}
+}
+
 ////////////////////////////////////////////////////////////
 // List command handler
 ////////////////////////////////////////////////////////////
@@ -270,3 +394,120 @@ func (server *Server) LLen(conn *redis.Conn, key string) (*redis.Message, error)
 
 	return redis.NewIntegerMessage(list.Len()), nil
 }
+
+// lookupList returns the list of the specified key without creating it, and returns false if the key does not exist.
+func (server *Server) lookupList(conn *redis.Conn, key string) (*List, bool, error) {
+	db, err := server.GetDatabase(conn.Database())
+	if err != nil {
+		return nil, false, err
+	}
+
+	record, ok := db.GetRecord(key)
+	if !ok {
+		return nil, false, nil
+	}
+
+	list, ok := record.Data.(*List)
+	if !ok {
+		return nil, false, fmt.Errorf(errorInvalidStoredDataType, record.Data)
+	}
+
+	return list, true, nil
+}
+
+func (server *Server) LSet(conn *redis.Conn, key string, idx int, elem string) (*redis.Message, error) {
+	list, ok, err := server.lookupList(conn, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, redis.ErrNoSuchKey
+	}
+
+	if !list.Set(idx, elem) {
+		return nil, redis.ErrIndexOutOfRange
+	}
+
+	return redis.NewOKMessage(), nil
+}
+
+func (server *Server) LInsert(conn *redis.Conn, key string, pivot string, elem string, opt redis.LInsertOption) (*redis.Message, error) {
+	list, ok, err := server.lookupList(conn, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return redis.NewIntegerMessage(0), nil
+	}
+
+	return redis.NewIntegerMessage(list.Insert(pivot, elem, opt.BEFORE)), nil
+}
+
+func (server *Server) LRem(conn *redis.Conn, key string, count int, elem string) (*redis.Message, error) {
+	list, ok, err := server.lookupList(conn, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return redis.NewIntegerMessage(0), nil
+	}
+
+	return redis.NewIntegerMessage(list.Rem(count, elem)), nil
+}
+
+func (server *Server) LTrim(conn *redis.Conn, key string, start int, stop int) (*redis.Message, error) {
+	list, ok, err := server.lookupList(conn, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		list.Trim(start, stop)
+	}
+
+	return redis.NewOKMessage(), nil
+}
+
+func (server *Server) LMove(conn *redis.Conn, src string, dst string, from redis.ListDirection, to redis.ListDirection) (*redis.Message, error) {
+	srcList, ok, err := server.lookupList(conn, src)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok || srcList.Len() == 0 {
+		return redis.NewNilMessage(), nil
+	}
+
+	db, err := server.GetDatabase(conn.Database())
+	if err != nil {
+		return nil, err
+	}
+
+	_, dstList, err := db.GetListRecord(dst)
+	if err != nil {
+		return nil, err
+	}
+
+	var elems []string
+	if from == redis.ListLeft {
+		elems, _ = srcList.LPop(1)
+	} else {
+		elems, _ = srcList.RPop(1)
+	}
+
+	// Checks the popped element again because the list may be emptied after the above check.
+	if len(elems) == 0 {
+		return redis.NewNilMessage(), nil
+	}
+
+	if to == redis.ListLeft {
+		dstList.LPush(elems)
+	} else {
+		dstList.RPush(elems)
+	}
+
+	return redis.NewBulkMessage(elems[0]), nil
+}
@@ -14,7 +14,10 @@
 
 package server
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Record represents a database record.
 type Record struct {
@@ -23,3 +26,54 @@ type Record struct {
 	Timestamp time.Time
 	TTL       time.Duration
 }
+
+// ExpireTime returns the expiration time of the record, or the zero time if the record has no expiration.
+func (record *Record) ExpireTime() time.Time {
+	if record.TTL <= 0 {
+		return time.Time{}
+	}
+
+	return record.Timestamp.Add(record.TTL)
+}
+
+// IsExpired returns true if the record has an expiration which has passed at the specified time.
+func (record *Record) IsExpired(now time.Time) bool {
+	return 0 < record.TTL && !now.Before(record.Timestamp.Add(record.TTL))
+}
+
+// SetExpireTime sets the expiration time of the record, and the zero time removes the expiration.
+func (record *Record) SetExpireTime(t time.Time) {
+	if t.IsZero() {
+		record.TTL = 0
+		return
+	}
+
+	record.TTL = t.Sub(record.Timestamp)
+}
+
+// CopyWithKey returns a copy of the record with the specified key.
+func (record *Record) CopyWithKey(key string) (*Record, error) {
+	var data any
+
+	switch v := record.Data.(type) {
+	case string:
+		data = v
+	case Hash:
+		data = v.Copy()
+	case *List:
+		data = v.Copy()
+	case *Set:
+		data = v.Copy()
+	case *ZSet:
+		data = v.Copy()
+	default:
+		return nil, fmt.Errorf(errorInvalidStoredDataType, record.Data)
+	}
+
+	return &Record{
+		Key:       key,
+		Data:      data,
+		Timestamp: record.Timestamp,
+		TTL:       record.TTL,
+	}, nil
+}
@@ -17,29 +17,48 @@ package server
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Records represents a database record map.
+// Expired records are removed lazily when they are accessed, and actively by the sampling of ActiveExpire.
 type Records struct {
 	sync.Map
+	expiresMutex  *sync.Mutex
+	expires       map[string]struct{}
+	lazyExpired   *atomic.Int64
+	activeExpired *atomic.Int64
 }
 
 func NewRecords() *Records {
 	return &Records{
-		Map: sync.Map{},
+		Map:           sync.Map{},
+		expiresMutex:  &sync.Mutex{},
+		expires:       map[string]struct{}{},
+		lazyExpired:   &atomic.Int64{},
+		activeExpired: &atomic.Int64{},
 	}
 }
 
 // Keys returns all key names.
 func (rmap *Records) Keys() []string {
 	keys := []string{}
+	now := time.Now()
 
 	rmap.Range(func(key, value any) bool {
 		skey, ok := key.(string)
-		if ok {
-			keys = append(keys, skey)
+		if !ok {
+			return true
 		}
 
+		if record, ok := value.(*Record); ok && record.IsExpired(now) {
+			rmap.expireRecord(record, rmap.lazyExpired)
+			return true
+		}
+
+		keys = append(keys, skey)
+
 		return true
 	})
 
@@ -49,12 +68,14 @@ func (rmap *Records) Keys() []string {
 // SetRecord sets the specified record into the records.
 func (rmap *Records) SetRecord(record *Record) error {
 	rmap.Store(record.Key, record)
+	rmap.updateExpireIndex(record)
+
 	return nil
 }
 
 // HasRecord returns true if the database has the specified key record, otherwise false.
 func (rmap *Records) HasRecord(key string) bool {
-	_, ok := rmap.Load(key)
+	_, ok := rmap.GetRecord(key)
 	return ok
 }
 
@@ -66,8 +87,16 @@ func (rmap *Records) GetRecord(key string) (*Record, bool) {
 	}
 
 	record, ok := v.(*Record)
+	if !ok {
+		return nil, false
+	}
 
-	return record, ok
+	if record.IsExpired(time.Now()) {
+		rmap.expireRecord(record, rmap.lazyExpired)
+		return nil, false
+	}
+
+	return record, true
 }
 
 // RemoveRecord removes a record with the specified key.
@@ -77,6 +106,7 @@ func (rmap *Records) RemoveRecord(key string) error {
 	}
 
 	rmap.Delete(key)
+	rmap.removeExpireIndex(key)
 
 	return nil
 }
@@ -88,9 +118,11 @@ func (rmap *Records) RenameRecord(key string, newkey string) error {
 		return fmt.Errorf("%w: %s", ErrNotFound, key)
 	}
 
-	record.Key = newkey
+	// Renames a copy not to change the key of the record which other goroutines may read.
+	newRecord := *record
+	newRecord.Key = newkey
 
-	err := rmap.SetRecord(record)
+	err := rmap.SetRecord(&newRecord)
 	if err != nil {
 		return err
 	}
@@ -102,3 +134,99 @@ func (rmap *Records) RenameRecord(key string, newkey string) error {
 
 	return nil
 }
+
+// SetExpireTime sets the expiration time of the record, and the zero time removes the expiration.
+// The record is replaced with a copy having the new expiration not to change the record which other goroutines may read,
+// and false is returned if the record has been already replaced or removed.
+func (rmap *Records) SetExpireTime(record *Record, t time.Time) bool {
+	newRecord := *record
+	newRecord.SetExpireTime(t)
+
+	if !rmap.CompareAndSwap(record.Key, record, &newRecord) {
+		return false
+	}
+
+	rmap.updateExpireIndex(&newRecord)
+
+	return true
+}
+
+// ActiveExpire samples up to the specified number of keys with expirations, removes the expired keys,
+// and returns the numbers of the sampled and expired keys.
+func (rmap *Records) ActiveExpire(samples int) (int, int) {
+	rmap.expiresMutex.Lock()
+
+	keys := make([]string, 0, samples)
+
+	// The iteration order of maps is randomized, so the first keys are random samples.
+	for key := range rmap.expires {
+		if samples <= len(keys) {
+			break
+		}
+
+		keys = append(keys, key)
+	}
+
+	rmap.expiresMutex.Unlock()
+
+	expired := 0
+	now := time.Now()
+
+	for _, key := range keys {
+		v, ok := rmap.Load(key)
+		if !ok {
+			rmap.removeExpireIndex(key)
+			continue
+		}
+
+		record, ok := v.(*Record)
+		if !ok || !record.IsExpired(now) {
+			continue
+		}
+
+		if rmap.expireRecord(record, rmap.activeExpired) {
+			expired++
+		}
+	}
+
+	return len(keys), expired
+}
+
+// ExpireStats returns the numbers of the keys expired lazily and actively.
+func (rmap *Records) ExpireStats() ExpireStats {
+	return ExpireStats{
+		LazyExpiredKeys:   rmap.lazyExpired.Load(),
+		ActiveExpiredKeys: rmap.activeExpired.Load(),
+	}
+}
+
+// expireRecord removes the expired record unless the key has been replaced with a new record, and increments the counter.
+func (rmap *Records) expireRecord(record *Record, counter *atomic.Int64) bool {
+	if !rmap.CompareAndDelete(record.Key, record) {
+		return false
+	}
+
+	rmap.removeExpireIndex(record.Key)
+	counter.Add(1)
+
+	return true
+}
+
+func (rmap *Records) updateExpireIndex(record *Record) {
+	rmap.expiresMutex.Lock()
+	defer rmap.expiresMutex.Unlock()
+
+	if record.TTL <= 0 {
+		delete(rmap.expires, record.Key)
+		return
+	}
+
+	rmap.expires[record.Key] = struct{}{}
+}
+
+func (rmap *Records) removeExpireIndex(key string) {
+	rmap.expiresMutex.Lock()
+	defer rmap.expiresMutex.Unlock()
+
+	delete(rmap.expires, key)
+}
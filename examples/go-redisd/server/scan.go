@@ -0,0 +1,284 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-redis/redis"
+	"github.com/cybergarage/go-redis/redis/proto"
+)
+
+const (
+	// scanCursorTTL is the duration to remember the cursors which are not used.
+	scanCursorTTL = 10 * time.Minute
+	// maxScanCursors is the maximum number of the remembered cursors.
+	maxScanCursors = 64 * 1024
+)
+
+// scanScope represents the target of a cursor.
+type scanScope struct {
+	db  redis.DatabaseID
+	typ redis.ScanType
+	key string
+}
+
+// scanCursor represents the last returned element of a cursor.
+type scanCursor struct {
+	scope  scanScope
+	elem   string
+	stored time.Time
+}
+
+// ScanCursors represents a reference cursor implementation of the SCAN family commands.
+// A cursor remembers the last returned element, and the next iteration continues from the next element in the sorted order,
+// so every element which is present for the whole iteration is returned even while elements are added or removed.
+// A cursor is valid only for the database, the command and the key which returned it, and only until the next iteration uses it.
+// The cursors are forgotten after they are not used for scanCursorTTL, or the oldest cursor is forgotten
+// if maxScanCursors cursors are remembered.
+type ScanCursors struct {
+	mutex     *sync.Mutex
+	firstID   int
+	lastID    int
+	lastPurge time.Time
+	cursors   map[int]*scanCursor
+}
+
+// NewScanCursors returns a new cursor set.
+func NewScanCursors() *ScanCursors {
+	return &ScanCursors{
+		mutex:     &sync.Mutex{},
+		firstID:   1,
+		lastID:    0,
+		lastPurge: time.Now(),
+		cursors:   map[int]*scanCursor{},
+	}
+}
+
+// store remembers the specified last returned element, and returns the new cursor.
+func (cursors *ScanCursors) store(scope scanScope, elem string) int {
+	cursors.mutex.Lock()
+	defer cursors.mutex.Unlock()
+
+	now := time.Now()
+
+	// Forgets the cursors which are not used for a while.
+	if scanCursorTTL <= now.Sub(cursors.lastPurge) {
+		for id, cursor := range cursors.cursors {
+			if scanCursorTTL <= now.Sub(cursor.stored) {
+				delete(cursors.cursors, id)
+			}
+		}
+
+		cursors.lastPurge = now
+	}
+
+	// Forgets the oldest cursor not to grow without bound, the cursor IDs are increased in the stored order.
+	if maxScanCursors <= len(cursors.cursors) {
+		for ; cursors.firstID <= cursors.lastID; cursors.firstID++ {
+			if _, ok := cursors.cursors[cursors.firstID]; ok {
+				delete(cursors.cursors, cursors.firstID)
+				break
+			}
+		}
+	}
+
+	cursors.lastID++
+	cursors.cursors[cursors.lastID] = &scanCursor{scope: scope, elem: elem, stored: now}
+
+	return cursors.lastID
+}
+
+// load returns the last returned element of the specified cursor, and forgets the cursor which is used.
+func (cursors *ScanCursors) load(scope scanScope, cursor int) (string, bool) {
+	cursors.mutex.Lock()
+	defer cursors.mutex.Unlock()
+
+	c, ok := cursors.cursors[cursor]
+	if !ok || c.scope != scope {
+		return "", false
+	}
+
+	delete(cursors.cursors, cursor)
+
+	return c.elem, true
+}
+
+// Scan returns the next matched elements of the specified elements of the key in the database from the cursor, and the next cursor.
+func (cursors *ScanCursors) Scan(db redis.DatabaseID, key string, elems []string, cursor int, opt redis.ScanOption) ([]string, int, error) {
+	sort.Strings(elems)
+
+	scope := scanScope{db: db, typ: opt.Type, key: key}
+	start := 0
+
+	if cursor != 0 {
+		last, ok := cursors.load(scope, cursor)
+		if !ok {
+			return nil, 0, fmt.Errorf(errorInvalidCursor, cursor)
+		}
+
+		start = sort.SearchStrings(elems, last)
+		if start < len(elems) && elems[start] == last {
+			start++
+		}
+	}
+
+	matchElems := []string{}
+
+	for n := start; n < len(elems); n++ {
+		if !opt.MatchPattern.MatchString(elems[n]) {
+			continue
+		}
+
+		matchElems = append(matchElems, elems[n])
+
+		if opt.Count <= len(matchElems) && n+1 < len(elems) {
+			return matchElems, cursors.store(scope, elems[n]), nil
+		}
+	}
+
+	return matchElems, 0, nil
+}
+
+// newScanMessage returns a reply message of the SCAN family commands.
+func newScanMessage(cursor int, elems []string, vals map[string]*redis.Message) *redis.Message {
+	array := proto.NewArray()
+
+	for _, elem := range elems {
+		array.Append(redis.NewBulkMessage(elem))
+
+		if val, ok := vals[elem]; ok {
+			array.Append(val)
+		}
+	}
+
+	return redis.NewScanMessage(cursor, array)
+}
+
+func (server *Server) Scan(conn *redis.Conn, cursor int, opt redis.ScanOption) (*redis.Message, error) {
+	db, err := server.GetDatabase(conn.Database())
+	if err != nil {
+		return nil, err
+	}
+
+	keys, nextCursor, err := server.cursors.Scan(conn.Database(), "", db.Keys(), cursor, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return newScanMessage(nextCursor, keys, nil), nil
+}
+
+func (server *Server) HScan(conn *redis.Conn, key string, cursor int, opt redis.ScanOption) (*redis.Message, error) {
+	db, err := server.GetDatabase(conn.Database())
+	if err != nil {
+		return nil, err
+	}
+
+	hash := Hash{}
+
+	if record, ok := db.GetRecord(key); ok {
+		hash, ok = record.Data.(Hash)
+		if !ok {
+			return nil, fmt.Errorf(errorInvalidStoredDataType, record.Data)
+		}
+	}
+
+	fields := make([]string, 0, len(hash))
+	for field := range hash {
+		fields = append(fields, field)
+	}
+
+	fields, nextCursor, err := server.cursors.Scan(conn.Database(), key, fields, cursor, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	vals := map[string]*redis.Message{}
+
+	if !opt.NoValues {
+		for _, field := range fields {
+			vals[field] = redis.NewBulkMessage(hash[field])
+		}
+	}
+
+	return newScanMessage(nextCursor, fields, vals), nil
+}
+
+func (server *Server) SScan(conn *redis.Conn, key string, cursor int, opt redis.ScanOption) (*redis.Message, error) {
+	db, err := server.GetDatabase(conn.Database())
+	if err != nil {
+		return nil, err
+	}
+
+	members := []string{}
+
+	if record, ok := db.GetRecord(key); ok {
+		set, ok := record.Data.(*Set)
+		if !ok {
+			return nil, fmt.Errorf(errorInvalidStoredDataType, record.Data)
+		}
+
+		members = append(members, set.Members()...)
+	}
+
+	members, nextCursor, err := server.cursors.Scan(conn.Database(), key, members, cursor, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return newScanMessage(nextCursor, members, nil), nil
+}
+
+func (server *Server) ZScan(conn *redis.Conn, key string, cursor int, opt redis.ScanOption) (*redis.Message, error) {
+	db, err := server.GetDatabase(conn.Database())
+	if err != nil {
+		return nil, err
+	}
+
+	scores := map[string]float64{}
+
+	if record, ok := db.GetRecord(key); ok {
+		zset, ok := record.Data.(*ZSet)
+		if !ok {
+			return nil, fmt.Errorf(errorInvalidStoredDataType, record.Data)
+		}
+
+		for _, m := range zset.members {
+			scores[m.Member] = m.Score
+		}
+	}
+
+	members := make([]string, 0, len(scores))
+	for member := range scores {
+		members = append(members, member)
+	}
+
+	members, nextCursor, err := server.cursors.Scan(conn.Database(), key, members, cursor, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	vals := map[string]*redis.Message{}
+	for _, member := range members {
+		vals[member] = redis.NewBulkMessage(strconv.FormatFloat(scores[member], 'g', -1, 64))
+	}
+
+	return newScanMessage(nextCursor, members, vals), nil
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cybergarage/go-redis/redis"
+	"github.com/cybergarage/go-redis/redis/glob"
+)
+
+func TestScanCursors(t *testing.T) {
+	cursors := NewScanCursors()
+
+	opt := redis.ScanOption{
+		MatchPattern: glob.MustCompile("key*"),
+		Count:        2,
+		Type:         redis.KeyScan,
+		KeyType:      "",
+		NoValues:     false,
+	}
+
+	elems := []string{"key4", "key1", "other", "key3", "key2"}
+
+	res, cursor, err := cursors.Scan(0, "", elems, 0, opt)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if expected := []string{"key1", "key2"}; !reflect.DeepEqual(res, expected) {
+		t.Errorf("%v != %v", res, expected)
+	}
+
+	if cursor == 0 {
+		t.Errorf("%d == %d", cursor, 0)
+		return
+	}
+
+	// Other iterations do not invalidate the cursor.
+	for range 2048 {
+		if _, _, err := cursors.Scan(0, "", elems, 0, opt); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	// The cursor is valid only for the database, the command and the key which returned it.
+	for _, scope := range []scanScope{{db: 1, typ: opt.Type, key: ""}, {db: 0, typ: opt.Type, key: "key"}} {
+		if _, _, err := cursors.Scan(scope.db, scope.key, elems, cursor, opt); err == nil {
+			t.Errorf("cursor %d should be invalid for %v", cursor, scope)
+		}
+	}
+
+	// Elements which are present for the whole iteration are returned even if the other elements are removed.
+	elems = []string{"key3", "key0", "key4", "other"}
+
+	res, cursor, err = cursors.Scan(0, "", elems, cursor, opt)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if expected := []string{"key3", "key4"}; !reflect.DeepEqual(res, expected) {
+		t.Errorf("%v != %v", res, expected)
+	}
+
+	if cursor == 0 {
+		t.Errorf("%d == %d", cursor, 0)
+		return
+	}
+
+	res, cursor, err = cursors.Scan(0, "", elems, cursor, opt)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(res) != 0 || cursor != 0 {
+		t.Errorf("%v, %d != [], %d", res, cursor, 0)
+	}
+
+	if _, _, err := cursors.Scan(0, "", elems, 12345, opt); err == nil {
+		t.Errorf("cursor %d should be invalid", 12345)
+	}
+
+	// The number of the remembered cursors is bounded even if iterations are not continued.
+	for range maxScanCursors + 1 {
+		if _, _, err := cursors.Scan(0, "", elems, 0, opt); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	if n := len(cursors.cursors); maxScanCursors < n {
+		t.Errorf("%d > %d", n, maxScanCursors)
+	}
+}
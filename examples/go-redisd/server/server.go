@@ -15,6 +15,9 @@
 package server
 
 import (
+	"context"
+	"errors"
+
 	"github.com/cybergarage/go-redis/redis"
 )
 
@@ -22,13 +25,17 @@ import (
 type Server struct {
 	redis.Server
 	*Databases
+	cursors     *ScanCursors
+	expireCycle *expireCycle
 }
 
 // NewServer returns an example server instance.
 func NewServer() *Server {
 	server := &Server{
-		Server:    redis.NewServer(),
-		Databases: NewDatabases(),
+		Server:      redis.NewServer(),
+		Databases:   NewDatabases(),
+		cursors:     NewScanCursors(),
+		expireCycle: newExpireCycle(),
 	}
 	server.SetCommandHandler(server)
 
@@ -45,3 +52,44 @@ func (server *Server) GetDatabase(id redis.DatabaseID) (*Database, error) {
 
 	return db, nil
 }
+
+// Start starts the server and the active expiration cycle.
+func (server *Server) Start() error {
+	err := server.Server.Start()
+	if err != nil {
+		return err
+	}
+
+	// The cycle also stops when the server is shut down by SHUTDOWN command.
+	server.expireCycle.start(server.ActiveExpire, server.Done())
+
+	return nil
+}
+
+// Stop stops the active expiration cycle and the server.
+func (server *Server) Stop() error {
+	server.expireCycle.stop()
+	return server.Server.Stop()
+}
+
+// Restart restarts the server and the active expiration cycle.
+func (server *Server) Restart() error {
+	err := server.Stop()
+	if err != nil {
+		return err
+	}
+
+	return server.Start()
+}
+
+// Shutdown stops the server gracefully and the active expiration cycle unless the shutdown is aborted.
+func (server *Server) Shutdown(ctx context.Context) error {
+	err := server.Server.Shutdown(ctx)
+	if errors.Is(err, redis.ErrShutdownAborted) {
+		return err
+	}
+
+	server.expireCycle.stop()
+
+	return err
+}
@@ -15,6 +15,11 @@
 package server
 
 import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+
 	"github.com/cybergarage/go-redis/redis"
 )
 
@@ -22,30 +27,27 @@ import (
 // Set
 ////////////////////////////////////////////////////////////
 
+// Set represents a set value which is safe for concurrent use.
 type Set struct {
+	mutex   *sync.Mutex
 	members []string
 }
 
 func NewSet() *Set {
 	return &Set{
+		mutex:   &sync.Mutex{},
 		members: []string{},
 	}
 }
 
 func (set *Set) Add(members []string) int {
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
 	addedMemberCount := 0
 
 	for _, member := range members {
-		hasMember := false
-
-		for _, set := range set.members {
-			if set == member {
-				hasMember = true
-				continue
-			}
-		}
-
-		if hasMember {
+		if slices.Contains(set.members, member) {
 			continue
 		}
 
@@ -57,6 +59,13 @@ func (set *Set) Add(members []string) int {
 }
 
 func (set *Set) Rem(members []string) int {
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	return set.rem(members)
+}
+
+func (set *Set) rem(members []string) int {
 	removedMemberCount := 0
 
 	for _, rm := range members {
@@ -73,8 +82,73 @@ func (set *Set) Rem(members []string) int {
 	return removedMemberCount
 }
 
+// Members returns a copy of the members.
 func (set *Set) Members() []string {
-	return set.members
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	return slices.Clone(set.members)
+}
+
+// Pop removes up to the specified number of random members and returns them.
+func (set *Set) Pop(count int) []string {
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	members := set.randMembers(count)
+	set.rem(members)
+
+	return members
+}
+
+// RandMembers returns up to the specified number of distinct random members,
+// or the absolute number of random members which may be duplicated if the count is negative.
+func (set *Set) RandMembers(count int) []string {
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	return set.randMembers(count)
+}
+
+func (set *Set) randMembers(count int) []string {
+	if len(set.members) == 0 {
+		return []string{}
+	}
+
+	if count < 0 {
+		members := []string{}
+		for ; count < 0; count++ {
+			members = append(members, set.members[rand.IntN(len(set.members))])
+		}
+
+		return members
+	}
+
+	members := []string{}
+	for _, n := range rand.Perm(len(set.members))[:min(count, len(set.members))] {
+		members = append(members, set.members[n])
+	}
+
+	return members
+}
+
+// Has returns true if the set has the specified member.
+func (set *Set) Has(member string) bool {
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	return slices.Contains(set.members, member)
+}
+
+// Copy returns a copy of the set.
+func (set *Set) Copy() *Set {
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	return &Set{
+		mutex:   &sync.Mutex{},
+		members: slices.Clone(set.members),
+	}
 }
 
 ////////////////////////////////////////////////////////////
@@ -129,3 +203,133 @@ func (server *Server) SRem(conn *redis.Conn, key string, members []string) (*red
 
 	return redis.NewIntegerMessage(set.Rem(members)), nil
 }
+
+// lookupSets returns the sets of the specified keys without creating them, and missing keys are empty sets.
+func (server *Server) lookupSets(conn *redis.Conn, keys []string) ([]*Set, error) {
+	db, err := server.GetDatabase(conn.Database())
+	if err != nil {
+		return nil, err
+	}
+
+	sets := make([]*Set, len(keys))
+
+	for n, key := range keys {
+		sets[n] = NewSet()
+
+		record, ok := db.GetRecord(key)
+		if !ok {
+			continue
+		}
+
+		set, ok := record.Data.(*Set)
+		if !ok {
+			return nil, fmt.Errorf(errorInvalidStoredDataType, record.Data)
+		}
+
+		sets[n] = set
+	}
+
+	return sets, nil
+}
+
+func (server *Server) SInter(conn *redis.Conn, keys []string) (*redis.Message, error) {
+	sets, err := server.lookupSets(conn, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	members := []string{}
+
+	for _, member := range sets[0].Members() {
+		inAll := true
+
+		for _, set := range sets[1:] {
+			if !set.Has(member) {
+				inAll = false
+				break
+			}
+		}
+
+		if inAll {
+			members = append(members, member)
+		}
+	}
+
+	return redis.NewStringArrayMessage(members), nil
+}
+
+func (server *Server) SUnion(conn *redis.Conn, keys []string) (*redis.Message, error) {
+	sets, err := server.lookupSets(conn, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	union := NewSet()
+	for _, set := range sets {
+		union.Add(set.Members())
+	}
+
+	return redis.NewStringArrayMessage(union.Members()), nil
+}
+
+func (server *Server) SDiff(conn *redis.Conn, keys []string) (*redis.Message, error) {
+	sets, err := server.lookupSets(conn, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := sets[0].Copy()
+	for _, set := range sets[1:] {
+		diff.Rem(set.Members())
+	}
+
+	return redis.NewStringArrayMessage(diff.Members()), nil
+}
+
+func (server *Server) SPop(conn *redis.Conn, key string, count int) (*redis.Message, error) {
+	sets, err := server.lookupSets(conn, []string{key})
+	if err != nil {
+		return nil, err
+	}
+
+	return redis.NewStringArrayMessage(sets[0].Pop(count)), nil
+}
+
+func (server *Server) SRandMember(conn *redis.Conn, key string, count int) (*redis.Message, error) {
+	sets, err := server.lookupSets(conn, []string{key})
+	if err != nil {
+		return nil, err
+	}
+
+	return redis.NewStringArrayMessage(sets[0].RandMembers(count)), nil
+}
+
+func (server *Server) SMove(conn *redis.Conn, src string, dst string, member string) (*redis.Message, error) {
+	sets, err := server.lookupSets(conn, []string{src})
+	if err != nil {
+		return nil, err
+	}
+
+	if !sets[0].Has(member) {
+		return redis.NewIntegerMessage(0), nil
+	}
+
+	db, err := server.GetDatabase(conn.Database())
+	if err != nil {
+		return nil, err
+	}
+
+	_, dstSet, err := db.GetSetRecord(dst)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checks the removal again because the member may be removed after the above check.
+	if sets[0].Rem([]string{member}) == 0 {
+		return redis.NewIntegerMessage(0), nil
+	}
+
+	dstSet.Add([]string{member})
+
+	return redis.NewIntegerMessage(1), nil
+}
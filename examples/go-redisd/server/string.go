@@ -28,11 +28,11 @@ func (server *Server) Set(conn *redis.Conn, key string, val string, opt redis.Se
 
 	var oldVal []byte
 
-	hasOldRecord := false
+	var currRecord *Record
 
-	if opt.NX || opt.GET {
-		var currRecord *Record
+	hasOldRecord := false
 
+	if opt.NX || opt.GET || opt.KEEPTTL {
 		currRecord, hasOldRecord = db.GetRecord(key)
 
 		switch {
@@ -56,6 +56,20 @@ func (server *Server) Set(conn *redis.Conn, key string, val string, opt redis.Se
 		Timestamp: time.Now(),
 		TTL:       0,
 	}
+
+	switch {
+	case 0 < opt.EX:
+		record.TTL = opt.EX
+	case 0 < opt.PX:
+		record.TTL = opt.PX
+	case !opt.EXAT.IsZero():
+		record.SetExpireTime(opt.EXAT)
+	case !opt.PXAT.IsZero():
+		record.SetExpireTime(opt.PXAT)
+	case opt.KEEPTTL && hasOldRecord:
+		record.SetExpireTime(currRecord.ExpireTime())
+	}
+
 	db.SetRecord(record)
 
 	switch {
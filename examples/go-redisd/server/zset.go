@@ -207,6 +207,18 @@ func (zset *ZSet) IncBy(inc float64, member string) float64 {
 	return tm.Score
 }
 
+// Copy returns a copy of the sorted set.
+func (zset *ZSet) Copy() *ZSet {
+	members := make([]*ZSetMember, len(zset.members))
+	for n, member := range zset.members {
+		members[n] = NewZSetMember(member.Score, member.Member)
+	}
+
+	return &ZSet{
+		members: members,
+	}
+}
+
 ////////////////////////////////////////////////////////////
 // ZSet command handler
 ////////////////////////////////////////////////////////////
@@ -0,0 +1,256 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"strconv"
+
+	"github.com/cybergarage/go-redis/redis/proto"
+)
+
+// Arguments represents command arguments without the command name.
+type Arguments = *CommandArguments
+
+// CommandArguments represents an immutable view of command arguments.
+// The arguments can be read repeatedly by index, and also read in order with a cursor.
+type CommandArguments struct {
+	msgs  []*Message
+	index int
+}
+
+// NewArguments returns new command arguments with the specified messages.
+func NewArguments(msgs ...*Message) Arguments {
+	return &CommandArguments{
+		msgs:  msgs,
+		index: 0,
+	}
+}
+
+// NewStringArguments returns new command arguments with the specified strings as bulk strings.
+func NewStringArguments(strs ...string) Arguments {
+	msgs := make([]*Message, len(strs))
+	for n, str := range strs {
+		msgs[n] = NewBulkMessage(str)
+	}
+
+	return NewArguments(msgs...)
+}
+
+// NewBytesArguments returns new command arguments with the specified bytes as bulk strings.
+func NewBytesArguments(vals ...[]byte) Arguments {
+	msgs := make([]*Message, len(vals))
+	for n, val := range vals {
+		msgs[n] = NewBulkMessageBytes(val)
+	}
+
+	return NewArguments(msgs...)
+}
+
+// newArgumentsWithArray returns new command arguments with the unread messages of the specified array.
+func newArgumentsWithArray(array *proto.Array) (Arguments, error) {
+	msgs, err := array.Copy().NextMessages()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewArguments(msgs...), nil
+}
+
+// Len returns the number of the arguments.
+func (args *CommandArguments) Len() int {
+	return len(args.msgs)
+}
+
+// At returns the argument message at the specified index.
+func (args *CommandArguments) At(n int) (*Message, error) {
+	if n < 0 || len(args.msgs) <= n {
+		return nil, proto.ErrEOM
+	}
+
+	return args.msgs[n], nil
+}
+
+// Bytes returns the raw bytes of the argument at the specified index.
+func (args *CommandArguments) Bytes(n int) ([]byte, error) {
+	msg, err := args.At(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg.Bytes()
+}
+
+// String returns the string of the argument at the specified index.
+func (args *CommandArguments) String(n int) (string, error) {
+	msg, err := args.At(n)
+	if err != nil {
+		return "", err
+	}
+
+	return msg.String()
+}
+
+// Int returns the integer of the argument at the specified index.
+func (args *CommandArguments) Int(n int) (int, error) {
+	msg, err := args.At(n)
+	if err != nil {
+		return 0, err
+	}
+
+	return msg.Integer()
+}
+
+// Float returns the floating point number of the argument at the specified index.
+func (args *CommandArguments) Float(n int) (float64, error) {
+	str, err := args.String(n)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseFloat(str, 64)
+}
+
+// Strings returns the strings of all arguments.
+func (args *CommandArguments) Strings() ([]string, error) {
+	strs := make([]string, len(args.msgs))
+	for n, msg := range args.msgs {
+		str, err := msg.String()
+		if err != nil {
+			return nil, err
+		}
+
+		strs[n] = str
+	}
+
+	return strs, nil
+}
+
+// Slice returns the view of the arguments from the specified index to the end index excluding it.
+// The indexes are clamped to the range of the arguments, and the cursor of the returned view is at the beginning.
+func (args *CommandArguments) Slice(from int, to int) Arguments {
+	from = min(max(from, 0), len(args.msgs))
+	to = min(max(to, from), len(args.msgs))
+
+	return NewArguments(args.msgs[from:to:to]...)
+}
+
+// Copy returns a copy of the arguments which has the same cursor position and reads independently.
+func (args *CommandArguments) Copy() Arguments {
+	return &CommandArguments{
+		msgs:  args.msgs,
+		index: args.index,
+	}
+}
+
+// Cursor functions
+
+// Pos returns the cursor position which is the index of the next argument.
+func (args *CommandArguments) Pos() int {
+	return args.index
+}
+
+// Rewind moves the cursor to the beginning of the arguments.
+func (args *CommandArguments) Rewind() {
+	args.index = 0
+}
+
+// Rest returns the view of the unread arguments.
+func (args *CommandArguments) Rest() Arguments {
+	return args.Slice(args.index, len(args.msgs))
+}
+
+// Next returns the next argument message, or nil if all arguments are read.
+func (args *CommandArguments) Next() (*Message, error) {
+	if len(args.msgs) <= args.index {
+		return nil, nil
+	}
+
+	msg := args.msgs[args.index]
+	args.index++
+
+	return msg, nil
+}
+
+// NextMessage returns the next argument message if any, otherwise it returns an error.
+func (args *CommandArguments) NextMessage() (*Message, error) {
+	msg, err := args.At(args.index)
+	if err != nil {
+		return nil, err
+	}
+
+	args.index++
+
+	return msg, nil
+}
+
+// NextMessages returns all unread argument messages.
+func (args *CommandArguments) NextMessages() ([]*Message, error) {
+	msgs := args.Rest().msgs
+	args.index = len(args.msgs)
+
+	return msgs, nil
+}
+
+// NextStrings returns the strings of all unread arguments.
+func (args *CommandArguments) NextStrings() ([]string, error) {
+	strs, err := args.Rest().Strings()
+	if err != nil {
+		return nil, err
+	}
+
+	args.index = len(args.msgs)
+
+	return strs, nil
+}
+
+// NextBytes returns the raw bytes of the next argument.
+func (args *CommandArguments) NextBytes() ([]byte, error) {
+	msg, err := args.NextMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	return msg.Bytes()
+}
+
+// NextString returns the string of the next argument.
+func (args *CommandArguments) NextString() (string, error) {
+	msg, err := args.NextMessage()
+	if err != nil {
+		return "", err
+	}
+
+	return msg.String()
+}
+
+// NextInteger returns the integer of the next argument.
+func (args *CommandArguments) NextInteger() (int, error) {
+	msg, err := args.NextMessage()
+	if err != nil {
+		return 0, err
+	}
+
+	return msg.Integer()
+}
+
+// NextFloat returns the floating point number of the next argument.
+func (args *CommandArguments) NextFloat() (float64, error) {
+	str, err := args.NextString()
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseFloat(str, 64)
+}
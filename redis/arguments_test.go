@@ -0,0 +1,97 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-redis/redis/proto"
+)
+
+func TestArguments(t *testing.T) {
+	args := NewStringArguments("key", "10", "1.5", "\x00\xff")
+
+	if n := args.Len(); n != 4 {
+		t.Errorf("%d != %d", n, 4)
+	}
+
+	// Reads the arguments in order with the cursor.
+
+	key, err := args.NextString()
+	if err != nil || key != "key" {
+		t.Errorf("%q != %q (%v)", key, "key", err)
+	}
+
+	i, err := args.NextInteger()
+	if err != nil || i != 10 {
+		t.Errorf("%d != %d (%v)", i, 10, err)
+	}
+
+	if pos := args.Pos(); pos != 2 {
+		t.Errorf("%d != %d", pos, 2)
+	}
+
+	// Reads the arguments by index regardless of the cursor.
+
+	str, err := args.String(0)
+	if err != nil || str != "key" {
+		t.Errorf("%q != %q (%v)", str, "key", err)
+	}
+
+	f, err := args.Float(2)
+	if err != nil || f != 1.5 {
+		t.Errorf("%f != %f (%v)", f, 1.5, err)
+	}
+
+	b, err := args.Bytes(3)
+	if err != nil || string(b) != "\x00\xff" {
+		t.Errorf("%q != %q (%v)", b, "\x00\xff", err)
+	}
+
+	if _, err := args.At(4); !errors.Is(err, proto.ErrEOM) {
+		t.Errorf("%v != %v", err, proto.ErrEOM)
+	}
+
+	rest := args.Rest()
+	if n := rest.Len(); n != 2 {
+		t.Errorf("%d != %d", n, 2)
+	}
+
+	strs, err := args.NextStrings()
+	if err != nil || len(strs) != 2 {
+		t.Errorf("%v (%v)", strs, err)
+	}
+
+	if _, err := args.NextString(); !errors.Is(err, proto.ErrEOM) {
+		t.Errorf("%v != %v", err, proto.ErrEOM)
+	}
+
+	// The rest view is not affected by the cursor.
+
+	if str, _ := rest.NextString(); str != "1.5" {
+		t.Errorf("%q != %q", str, "1.5")
+	}
+
+	if n := args.Slice(1, 100).Len(); n != 3 {
+		t.Errorf("%d != %d", n, 3)
+	}
+
+	args.Rewind()
+
+	if str, _ := args.NextString(); str != "key" {
+		t.Errorf("%q != %q", str, "key")
+	}
+}
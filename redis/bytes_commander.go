@@ -0,0 +1,457 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"fmt"
+	"time"
+)
+
+// registerBytesExecutor registers the executor which calls the bytes handler if the user handler implements it,
+// otherwise the executor calls the registered executor with the string handlers.
+func registerBytesExecutor[T any](server *server, cmd string, executor func(handler T, conn *Conn, cmd string, args Arguments) (*Message, error)) {
+	stringExecutor := server.commandExecutors[cmd]
+	server.RegisterExexutor(cmd, func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		handler, ok := server.userCommandHandler.(T)
+		if !ok {
+			return stringExecutor(conn, cmd, args)
+		}
+
+		return executor(handler, conn, cmd, args)
+	})
+}
+
+// nolint: gocyclo, maintidx
+func (server *server) registerBytesExecutors() {
+	// String commands.
+
+	registerBytesExecutor(server, "GET", func(handler StringBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextBytesArgument(cmd, "key", args)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.GetBytes(conn, key)
+	})
+
+	setExecutor := func(handler StringBytesCommandHandler, conn *Conn, cmd string, args Arguments, opt SetOption) (*Message, error) {
+		key, err := nextBytesArgument(cmd, "key", args)
+		if err != nil {
+			return nil, err
+		}
+
+		val, err := nextBytesArgument(cmd, "value", args)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.SetBytes(conn, key, val, opt)
+	}
+
+	registerBytesExecutor(server, "SET", func(handler StringBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		// Parses the options ahead of the key and value.
+		opt, err := nextSetOptionArguments(cmd, args.Slice(2, args.Len()))
+		if err != nil {
+			return nil, err
+		}
+
+		return setExecutor(handler, conn, cmd, args, opt)
+	})
+
+	registerBytesExecutor(server, "SETNX", func(handler StringBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		opt := newDefaultSetOption()
+		opt.NX = true
+
+		return setExecutor(handler, conn, cmd, args, opt)
+	})
+
+	registerBytesExecutor(server, "GETSET", func(handler StringBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		opt := newDefaultSetOption()
+		opt.GET = true
+
+		return setExecutor(handler, conn, cmd, args, opt)
+	})
+
+	registerBytesExecutor(server, "SETEX", func(handler StringBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextBytesArgument(cmd, "key", args)
+		if err != nil {
+			return nil, err
+		}
+
+		seconds, err := nextIntegerArgument(cmd, "seconds", args)
+		if err != nil {
+			return nil, err
+		}
+
+		if seconds < 1 {
+			return nil, newInvalidArgumentError(cmd, "seconds", fmt.Errorf(errorShouldBeGreaterThanInt, "argument", 0))
+		}
+
+		val, err := nextBytesArgument(cmd, "value", args)
+		if err != nil {
+			return nil, err
+		}
+
+		opt := newDefaultSetOption()
+		opt.EX = time.Duration(seconds) * time.Second
+
+		return handler.SetBytes(conn, key, val, opt)
+	})
+
+	registerBytesExecutor(server, "PSETEX", func(handler StringBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextBytesArgument(cmd, "key", args)
+		if err != nil {
+			return nil, err
+		}
+
+		milliseconds, err := nextIntegerArgument(cmd, "milliseconds", args)
+		if err != nil {
+			return nil, err
+		}
+
+		if milliseconds < 1 {
+			return nil, newInvalidArgumentError(cmd, "milliseconds", fmt.Errorf(errorShouldBeGreaterThanInt, "argument", 0))
+		}
+
+		val, err := nextBytesArgument(cmd, "value", args)
+		if err != nil {
+			return nil, err
+		}
+
+		opt := newDefaultSetOption()
+		opt.PX = time.Duration(milliseconds) * time.Millisecond
+
+		return handler.SetBytes(conn, key, val, opt)
+	})
+
+	registerBytesExecutor(server, "MSET", func(handler StringBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		pairs, err := nextBytesPairArguments(cmd, "value", args)
+		if err != nil {
+			return nil, err
+		}
+
+		opt := newDefaultSetOption()
+
+		for n := 0; n < len(pairs); n += 2 {
+			if _, err := handler.SetBytes(conn, pairs[n], pairs[n+1], opt); err != nil {
+				return nil, err
+			}
+		}
+
+		return NewOKMessage(), nil
+	})
+
+	registerBytesExecutor(server, "MSETNX", func(handler StringBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		pairs, err := nextBytesPairArguments(cmd, "value", args)
+		if err != nil {
+			return nil, err
+		}
+
+		for n := 0; n < len(pairs); n += 2 {
+			res, err := handler.GetBytes(conn, pairs[n])
+			if err != nil {
+				return nil, err
+			}
+
+			if !res.IsNil() {
+				return NewIntegerMessage(0), nil
+			}
+		}
+
+		opt := newDefaultSetOption()
+		opt.NX = true
+
+		for n := 0; n < len(pairs); n += 2 {
+			if _, err := handler.SetBytes(conn, pairs[n], pairs[n+1], opt); err != nil {
+				return nil, err
+			}
+		}
+
+		return NewIntegerMessage(1), nil
+	})
+
+	registerBytesExecutor(server, "MGET", func(handler StringBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		keys, err := nextBytesArrayArguments(cmd, "keys", args)
+		if err != nil {
+			return nil, err
+		}
+
+		arrayMsg := NewArrayMessage()
+
+		array, _ := arrayMsg.Array()
+		for _, key := range keys {
+			msg, err := handler.GetBytes(conn, key)
+			if err != nil {
+				return nil, err
+			}
+
+			array.Append(msg)
+		}
+
+		return arrayMsg, nil
+	})
+
+	// Hash commands.
+
+	registerBytesExecutor(server, "HDEL", func(handler HashBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextBytesArgument(cmd, "hash", args)
+		if err != nil {
+			return nil, err
+		}
+
+		fields, err := nextBytesArrayArguments(cmd, "fields", args)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.HDelBytes(conn, key, fields)
+	})
+
+	registerBytesExecutor(server, "HGET", func(handler HashBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextBytesArgument(cmd, "hash", args)
+		if err != nil {
+			return nil, err
+		}
+
+		field, err := nextBytesArgument(cmd, "field", args)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.HGetBytes(conn, key, field)
+	})
+
+	registerBytesExecutor(server, "HGETALL", func(handler HashBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextBytesArgument(cmd, "hash", args)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.HGetAllBytes(conn, key)
+	})
+
+	hsetExecutor := func(handler HashBytesCommandHandler, conn *Conn, cmd string, args Arguments, opt HSetOption) (*Message, error) {
+		key, err := nextBytesArgument(cmd, "hash", args)
+		if err != nil {
+			return nil, err
+		}
+
+		field, err := nextBytesArgument(cmd, "field", args)
+		if err != nil {
+			return nil, err
+		}
+
+		val, err := nextBytesArgument(cmd, "value", args)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.HSetBytes(conn, key, field, val, opt)
+	}
+
+	registerBytesExecutor(server, "HSET", func(handler HashBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		return hsetExecutor(handler, conn, cmd, args, HSetOption{NX: false})
+	})
+
+	registerBytesExecutor(server, "HSETNX", func(handler HashBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		return hsetExecutor(handler, conn, cmd, args, HSetOption{NX: true})
+	})
+
+	registerBytesExecutor(server, "HMSET", func(handler HashBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextBytesArgument(cmd, "hash", args)
+		if err != nil {
+			return nil, err
+		}
+
+		pairs, err := nextBytesPairArguments(cmd, "value", args)
+		if err != nil {
+			return nil, err
+		}
+
+		opt := HSetOption{NX: false}
+
+		for n := 0; n < len(pairs); n += 2 {
+			if _, err := handler.HSetBytes(conn, key, pairs[n], pairs[n+1], opt); err != nil {
+				return nil, err
+			}
+		}
+
+		return NewOKMessage(), nil
+	})
+
+	registerBytesExecutor(server, "HMGET", func(handler HashBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextBytesArgument(cmd, "hash", args)
+		if err != nil {
+			return nil, err
+		}
+
+		fields, err := nextBytesArrayArguments(cmd, "fields", args)
+		if err != nil {
+			return nil, err
+		}
+
+		arrayMsg := NewArrayMessage()
+
+		array, _ := arrayMsg.Array()
+		for _, field := range fields {
+			msg, err := handler.HGetBytes(conn, key, field)
+			if err != nil {
+				return nil, err
+			}
+
+			array.Append(msg)
+		}
+
+		return arrayMsg, nil
+	})
+
+	// List commands.
+
+	registerBytesExecutor(server, "LINDEX", func(handler ListBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextBytesArgument(cmd, "key", args)
+		if err != nil {
+			return nil, err
+		}
+
+		idx, err := nextIntegerArgument(cmd, "index", args)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.LIndexBytes(conn, key, idx)
+	})
+
+	registerBytesExecutor(server, "LLEN", func(handler ListBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextBytesArgument(cmd, "key", args)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.LLenBytes(conn, key)
+	})
+
+	registerBytesExecutor(server, "LRANGE", func(handler ListBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextBytesArgument(cmd, "key", args)
+		if err != nil {
+			return nil, err
+		}
+
+		start, err := nextIntegerArgument(cmd, "start", args)
+		if err != nil {
+			return nil, err
+		}
+
+		end, err := nextIntegerArgument(cmd, "end", args)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.LRangeBytes(conn, key, start, end)
+	})
+
+	popExecutor := func(conn *Conn, cmd string, args Arguments, pop func(*Conn, []byte, int) (*Message, error)) (*Message, error) {
+		key, err := nextBytesArgument(cmd, "key", args)
+		if err != nil {
+			return nil, err
+		}
+
+		cnt := 1
+		if 1 < args.Len() {
+			cnt, err = nextIntegerArgument(cmd, "count", args)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return pop(conn, key, cnt)
+	}
+
+	registerBytesExecutor(server, "LPOP", func(handler ListBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		return popExecutor(conn, cmd, args, handler.LPopBytes)
+	})
+
+	registerBytesExecutor(server, "RPOP", func(handler ListBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		return popExecutor(conn, cmd, args, handler.RPopBytes)
+	})
+
+	pushExecutor := func(conn *Conn, cmd string, args Arguments, opt PushOption, push func(*Conn, []byte, [][]byte, PushOption) (*Message, error)) (*Message, error) {
+		key, err := nextBytesArgument(cmd, "key", args)
+		if err != nil {
+			return nil, err
+		}
+
+		elems, err := nextBytesArrayArguments(cmd, "elements", args)
+		if err != nil {
+			return nil, err
+		}
+
+		return push(conn, key, elems, opt)
+	}
+
+	registerBytesExecutor(server, "LPUSH", func(handler ListBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		return pushExecutor(conn, cmd, args, PushOption{X: false}, handler.LPushBytes)
+	})
+
+	registerBytesExecutor(server, "LPUSHX", func(handler ListBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		return pushExecutor(conn, cmd, args, PushOption{X: true}, handler.LPushBytes)
+	})
+
+	registerBytesExecutor(server, "RPUSH", func(handler ListBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		return pushExecutor(conn, cmd, args, PushOption{X: false}, handler.RPushBytes)
+	})
+
+	registerBytesExecutor(server, "RPUSHX", func(handler ListBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		return pushExecutor(conn, cmd, args, PushOption{X: true}, handler.RPushBytes)
+	})
+
+	// Set commands.
+
+	registerBytesExecutor(server, "SADD", func(handler SetBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextBytesArgument(cmd, "key", args)
+		if err != nil {
+			return nil, err
+		}
+
+		members, err := nextBytesArrayArguments(cmd, "member", args)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.SAddBytes(conn, key, members)
+	})
+
+	registerBytesExecutor(server, "SMEMBERS", func(handler SetBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextBytesArgument(cmd, "key", args)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.SMembersBytes(conn, key)
+	})
+
+	registerBytesExecutor(server, "SREM", func(handler SetBytesCommandHandler, conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextBytesArgument(cmd, "key", args)
+		if err != nil {
+			return nil, err
+		}
+
+		members, err := nextBytesArrayArguments(cmd, "member", args)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.SRemBytes(conn, key, members)
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+)
+
+// testBytesCommandHandler is a user command handler which implements only the string bytes handlers.
+type testBytesCommandHandler struct {
+	testCommandHandler
+	values map[string][]byte
+	opts   map[string]SetOption
+}
+
+func (handler *testBytesCommandHandler) SetBytes(conn *Conn, key []byte, val []byte, opt SetOption) (*Message, error) {
+	handler.values[string(key)] = val
+	handler.opts[string(key)] = opt
+
+	return NewOKMessage(), nil
+}
+
+func (handler *testBytesCommandHandler) GetBytes(conn *Conn, key []byte) (*Message, error) {
+	val, ok := handler.values[string(key)]
+	if !ok {
+		return NewNilMessage(), nil
+	}
+
+	return NewBulkMessageBytes(val), nil
+}
+
+func TestServerBytesCommandHandler(t *testing.T) {
+	server, ok := NewServer().(*server)
+	if !ok {
+		t.Error("invalid server")
+		return
+	}
+
+	handler := &testBytesCommandHandler{
+		testCommandHandler: testCommandHandler{UserCommandHandler: nil},
+		values:             map[string][]byte{},
+		opts:               map[string]SetOption{},
+	}
+
+	server.SetCommandHandler(handler)
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+
+	conn := newConnWith(serverConn, nil)
+	defer conn.Close()
+
+	conn.SetAuthrized(true)
+
+	// Binary payloads such as protobufs are not valid UTF-8 strings.
+	key := []byte{0x00, 0x01, 0xfe}
+	val := []byte{0x0a, 0x03, 0xff, 0x00, 0x80}
+
+	_, err := server.executeCommand(conn, "SET", NewArguments(NewBulkMessageBytes(key), NewBulkMessageBytes(val), NewBulkMessage("EX"), NewBulkMessage("10")))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if ex := handler.opts[string(key)].EX; ex != 10*time.Second {
+		t.Errorf("%v != %v", ex, 10*time.Second)
+	}
+
+	_, err = server.executeCommand(conn, "MSET", NewBytesArguments([]byte("k1"), []byte{0xff}, []byte("k2"), []byte{0x00}))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	res, err := server.executeCommand(conn, "MGET", NewBytesArguments(key, []byte("k1"), []byte("k2"), []byte("k3")))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	array, err := res.Array()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := [][]byte{val, {0xff}, {0x00}, nil}
+	for _, e := range expected {
+		msg, err := array.NextMessage()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		b, _ := msg.Bytes()
+		if !bytes.Equal(b, e) || (e == nil) != msg.IsNil() {
+			t.Errorf("%v != %v", b, e)
+		}
+	}
+}
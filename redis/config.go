@@ -16,6 +16,8 @@ package redis
 
 import (
 	"crypto/tls"
+	"os"
+	"time"
 )
 
 // CertConfig represents a TLS configuration interface.
@@ -50,11 +52,95 @@ type TLSConfig interface {
 	TLSPort() int
 	// IsTLSPortEnabled returns true if a listen port for TLS is enabled.
 	IsTLSPortEnabled() bool
+	// TLSCertFile returns a loaded SSL server certificate file.
+	TLSCertFile() string
+	// TLSKeyFile returns a loaded SSL server key file.
+	TLSKeyFile() string
+	// TLSCACertFiles returns loaded SSL root certificate files.
+	TLSCACertFiles() []string
+	// SetTLSHandshakeTimeout sets the duration to wait for TLS handshakes to complete, 0 means no timeout.
+	SetTLSHandshakeTimeout(d time.Duration)
+	// TLSHandshakeTimeout returns the duration to wait for TLS handshakes to complete.
+	TLSHandshakeTimeout() time.Duration
+	// SetTLSAutoDetect sets whether to detect TLS connections on the listen port by peeking the first bytes.
+	SetTLSAutoDetect(enabled bool)
+	// IsTLSAutoDetectEnabled returns true if TLS connections are detected on the listen port.
+	IsTLSAutoDetectEnabled() bool
+	// SetTLSRequiredForRemote sets whether to refuse plaintext connections from non-loopback clients on the auto-detecting port.
+	SetTLSRequiredForRemote(required bool)
+	// IsTLSRequiredForRemote returns true if plaintext connections from non-loopback clients are refused on the auto-detecting port.
+	IsTLSRequiredForRemote() bool
+	// SetTLSReloadInterval sets the interval to check the certificate files for changes, 0 means disabled.
+	SetTLSReloadInterval(d time.Duration)
+	// TLSReloadInterval returns the interval to check the certificate files for changes.
+	TLSReloadInterval() time.Duration
+}
+
+// ProxyConfig represents a PROXY protocol configuration.
+type ProxyConfig interface {
+	// SetProxyProtocol sets whether to read PROXY protocol headers from accepted connections.
+	SetProxyProtocol(enabled bool)
+	// IsProxyProtocolEnabled returns true if PROXY protocol headers are read from accepted connections.
+	IsProxyProtocolEnabled() bool
+	// SetProxyProtocolTrustedSources sets IP addresses or CIDR blocks of proxies allowed to send PROXY protocol headers.
+	SetProxyProtocolTrustedSources(sources ...string)
+	// ProxyProtocolTrustedSources returns IP addresses or CIDR blocks of proxies allowed to send PROXY protocol headers, only loopback proxies are allowed if empty.
+	ProxyProtocolTrustedSources() []string
+}
+
+// ClientConfig represents a client connection configuration.
+type ClientConfig interface {
+	// SetProtectedMode sets whether to refuse non-loopback clients when no password or credentials are configured.
+	SetProtectedMode(enabled bool)
+	// IsProtectedModeEnabled returns true if the protected mode is enabled.
+	IsProtectedModeEnabled() bool
+	// SetMaxClients sets the maximum number of connected clients.
+	SetMaxClients(n int)
+	// MaxClients returns the maximum number of connected clients.
+	MaxClients() int
+	// SetMaxClientsPerIP sets the maximum number of connected clients from the same IP address.
+	SetMaxClientsPerIP(n int)
+	// MaxClientsPerIP returns the maximum number of connected clients from the same IP address.
+	MaxClientsPerIP() int
+	// SetAcceptRateLimit sets the maximum number of accepted connections per second.
+	SetAcceptRateLimit(n int)
+	// AcceptRateLimit returns the maximum number of accepted connections per second.
+	AcceptRateLimit() int
+	// SetIdleTimeout sets the duration to close idle clients, 0 means disabled.
+	SetIdleTimeout(d time.Duration)
+	// IdleTimeout returns the duration to close idle clients.
+	IdleTimeout() time.Duration
+	// SetCommandTimeout sets the duration to cancel the context of running commands, 0 means disabled.
+	SetCommandTimeout(d time.Duration)
+	// CommandTimeout returns the duration to cancel the context of running commands.
+	CommandTimeout() time.Duration
+	// SetTCPKeepAlive sets the period of TCP keepalive probes, 0 means disabled.
+	SetTCPKeepAlive(d time.Duration)
+	// TCPKeepAlive returns the period of TCP keepalive probes.
+	TCPKeepAlive() time.Duration
+	// SetProtoMaxBulkLen sets the maximum length of a request bulk string.
+	SetProtoMaxBulkLen(n int)
+	// ProtoMaxBulkLen returns the maximum length of a request bulk string.
+	ProtoMaxBulkLen() int
+	// SetProtoMaxMultiBulkLen sets the maximum number of elements of a request array.
+	SetProtoMaxMultiBulkLen(n int)
+	// ProtoMaxMultiBulkLen returns the maximum number of elements of a request array.
+	ProtoMaxMultiBulkLen() int
+	// SetClientQueryBufferLimit sets the maximum size of a client request.
+	SetClientQueryBufferLimit(n int)
+	// ClientQueryBufferLimit returns the maximum size of a client request.
+	ClientQueryBufferLimit() int
+	// SetClientOutputBufferLimit sets the output buffer limit for the client class of the specified limit.
+	SetClientOutputBufferLimit(limit OutputBufferLimit)
+	// ClientOutputBufferLimit returns the output buffer limit for the specified client class.
+	ClientOutputBufferLimit(class ClientClass) OutputBufferLimit
 }
 
 // Config represents a server configuration.
 type Config interface {
 	TLSConfig
+	ProxyConfig
+	ClientConfig
 
 	// SetPort sets a listen port number.
 	SetPort(port int)
@@ -62,6 +148,30 @@ type Config interface {
 	Port() int
 	// IsPortEnabled returns true if a listen port is enabled.
 	IsPortEnabled() bool
+	// SetBind sets addresses to listen on, an address prefixed with "-" is skipped if it is unavailable.
+	SetBind(addrs ...string)
+	// Bind returns addresses to listen on.
+	Bind() []string
+
+	// SetUnixSocket sets a path of the Unix domain socket to listen on.
+	SetUnixSocket(path string)
+	// UnixSocket returns a path of the Unix domain socket to listen on.
+	UnixSocket() string
+	// IsUnixSocketEnabled returns true if a Unix domain socket is enabled.
+	IsUnixSocketEnabled() bool
+	// SetUnixSocketPerm sets a file permission of the Unix domain socket.
+	SetUnixSocketPerm(perm os.FileMode)
+	// UnixSocketPerm returns a file permission of the Unix domain socket, 0 means the default permission.
+	UnixSocketPerm() os.FileMode
+
+	// SetShutdownTimeout sets the duration to wait for connections to be closed by SHUTDOWN command.
+	SetShutdownTimeout(d time.Duration)
+	// ShutdownTimeout returns the duration to wait for connections to be closed by SHUTDOWN command.
+	ShutdownTimeout() time.Duration
+	// SetShutdownAbortWindow sets the duration to keep serving clients before draining connections, in which SHUTDOWN ABORT can cancel the shutdown.
+	SetShutdownAbortWindow(d time.Duration)
+	// ShutdownAbortWindow returns the duration to keep serving clients before draining connections, in which SHUTDOWN ABORT can cancel the shutdown.
+	ShutdownAbortWindow() time.Duration
 
 	// SetRequirePass sets a password.
 	SetRequirePass(password string)
@@ -0,0 +1,180 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ClientClass represents a client class for the output buffer limits.
+type ClientClass int
+
+const (
+	NormalClient ClientClass = iota
+	ReplicaClient
+	PubSubClient
+)
+
+const (
+	normalClientClass  = "normal"
+	replicaClientClass = "replica"
+	slaveClientClass   = "slave"
+	pubsubClientClass  = "pubsub"
+)
+
+// String returns the class name.
+func (class ClientClass) String() string {
+	switch class {
+	case NormalClient:
+		return normalClientClass
+	case ReplicaClient:
+		return replicaClientClass
+	case PubSubClient:
+		return pubsubClientClass
+	}
+
+	return ""
+}
+
+func newClientClassFromString(str string) (ClientClass, error) {
+	switch strings.ToLower(str) {
+	case normalClientClass:
+		return NormalClient, nil
+	case replicaClientClass, slaveClientClass:
+		return ReplicaClient, nil
+	case pubsubClientClass:
+		return PubSubClient, nil
+	}
+
+	return 0, NewErrNotSupported(str)
+}
+
+// OutputBufferLimit represents an output buffer limit for a client class.
+// A client is disconnected if a pending response exceeds the hard limit,
+// or if it exceeds the soft limit for longer than the soft duration.
+type OutputBufferLimit struct {
+	Class        ClientClass
+	HardLimit    int
+	SoftLimit    int
+	SoftDuration time.Duration
+}
+
+// String returns the limit in the client-output-buffer-limit configuration format.
+func (limit OutputBufferLimit) String() string {
+	return fmt.Sprintf("%s %d %d %d", limit.Class, limit.HardLimit, limit.SoftLimit, int(limit.SoftDuration/time.Second))
+}
+
+func newDefaultOutputBufferLimits() []OutputBufferLimit {
+	return []OutputBufferLimit{
+		{Class: NormalClient, HardLimit: 0, SoftLimit: 0, SoftDuration: 0},
+		{Class: ReplicaClient, HardLimit: 256 << 20, SoftLimit: 64 << 20, SoftDuration: 60 * time.Second},
+		{Class: PubSubClient, HardLimit: 32 << 20, SoftLimit: 8 << 20, SoftDuration: 60 * time.Second},
+	}
+}
+
+// parseOutputBufferLimits parses the specified client-output-buffer-limit configuration such as "normal 0 0 0 pubsub 32mb 8mb 60".
+func parseOutputBufferLimits(str string) ([]OutputBufferLimit, error) {
+	const fieldsPerClass = 4
+
+	fields := strings.Fields(str)
+	if len(fields)%fieldsPerClass != 0 {
+		return nil, fmt.Errorf("%w client-output-buffer-limit (%s)", ErrInvalid, str)
+	}
+
+	limits := []OutputBufferLimit{}
+
+	for n := 0; n < len(fields); n += fieldsPerClass {
+		class, err := newClientClassFromString(fields[n])
+		if err != nil {
+			return nil, err
+		}
+
+		hard, err := parseMemorySize(fields[n+1])
+		if err != nil {
+			return nil, err
+		}
+
+		soft, err := parseMemorySize(fields[n+2])
+		if err != nil {
+			return nil, err
+		}
+
+		secs, err := strconv.Atoi(fields[n+3])
+		if err != nil {
+			return nil, err
+		}
+
+		if secs < 0 {
+			return nil, fmt.Errorf("%w client-output-buffer-limit (%s)", ErrInvalid, str)
+		}
+
+		limits = append(limits, OutputBufferLimit{
+			Class:        class,
+			HardLimit:    hard,
+			SoftLimit:    soft,
+			SoftDuration: time.Duration(secs) * time.Second,
+		})
+	}
+
+	return limits, nil
+}
+
+// parseMemorySize parses the specified memory size such as "1gb", "512mb" and "1024".
+func parseMemorySize(str string) (int, error) {
+	units := []struct {
+		suffix string
+		scale  int
+	}{
+		{suffix: "kb", scale: 1 << 10},
+		{suffix: "mb", scale: 1 << 20},
+		{suffix: "gb", scale: 1 << 30},
+		{suffix: "k", scale: 1000},
+		{suffix: "m", scale: 1000 * 1000},
+		{suffix: "g", scale: 1000 * 1000 * 1000},
+	}
+
+	lstr := strings.ToLower(str)
+	for _, unit := range units {
+		if !strings.HasSuffix(lstr, unit.suffix) {
+			continue
+		}
+
+		n, err := strconv.Atoi(strings.TrimSuffix(lstr, unit.suffix))
+		if err != nil {
+			return 0, err
+		}
+
+		if n < 0 || math.MaxInt/unit.scale < n {
+			return 0, fmt.Errorf("%w memory size (%s)", ErrInvalid, str)
+		}
+
+		return n * unit.scale, nil
+	}
+
+	n, err := strconv.Atoi(lstr)
+	if err != nil {
+		return 0, err
+	}
+
+	if n < 0 {
+		return 0, fmt.Errorf("%w memory size (%s)", ErrInvalid, str)
+	}
+
+	return n, nil
+}
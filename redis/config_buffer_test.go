@@ -0,0 +1,98 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemorySize(t *testing.T) {
+	tests := []struct {
+		str      string
+		expected int
+	}{
+		{"1024", 1024},
+		{"1k", 1000},
+		{"1kb", 1024},
+		{"512mb", 512 << 20},
+		{"1GB", 1 << 30},
+	}
+
+	for _, tt := range tests {
+		n, err := parseMemorySize(tt.str)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if n != tt.expected {
+			t.Errorf("%s: %d != %d", tt.str, n, tt.expected)
+		}
+	}
+}
+
+func TestOutputBufferLimitConfig(t *testing.T) {
+	cfg := newDefaultServerConfig()
+
+	limit := cfg.ClientOutputBufferLimit(PubSubClient)
+	if limit.HardLimit != 32<<20 || limit.SoftLimit != 8<<20 || limit.SoftDuration != 60*time.Second {
+		t.Errorf("%s", limit)
+	}
+
+	cfg.SetConfig(outputBufLimitConfig, "normal 1mb 512kb 10")
+
+	limit = cfg.ClientOutputBufferLimit(NormalClient)
+	if limit.HardLimit != 1<<20 || limit.SoftLimit != 512<<10 || limit.SoftDuration != 10*time.Second {
+		t.Errorf("%s", limit)
+	}
+
+	newLimit := OutputBufferLimit{Class: ReplicaClient, HardLimit: 1, SoftLimit: 2, SoftDuration: 3 * time.Second}
+	cfg.SetClientOutputBufferLimit(newLimit)
+
+	if limit := cfg.ClientOutputBufferLimit(ReplicaClient); limit != newLimit {
+		t.Errorf("%s != %s", limit, newLimit)
+	}
+
+	if limit := cfg.ClientOutputBufferLimit(NormalClient); limit.HardLimit != 1<<20 {
+		t.Errorf("%s", limit)
+	}
+}
+
+func TestQueryLimitConfig(t *testing.T) {
+	cfg := newDefaultServerConfig()
+
+	if n := cfg.ProtoMaxBulkLen(); n != DefaultProtoMaxBulkLen {
+		t.Errorf("%d != %d", n, DefaultProtoMaxBulkLen)
+	}
+
+	// The parsed limits are refreshed when the parameters are changed.
+	cfg.SetConfig(protoMaxBulkLenConfig, "1mb")
+	cfg.SetConfig(queryBufLimitConfig, "2mb")
+
+	if n := cfg.ProtoMaxBulkLen(); n != 1<<20 {
+		t.Errorf("%d != %d", n, 1<<20)
+	}
+
+	if n := cfg.ClientQueryBufferLimit(); n != 2<<20 {
+		t.Errorf("%d != %d", n, 2<<20)
+	}
+
+	cfg.RemoveConfig(protoMaxBulkLenConfig)
+
+	if n := cfg.ProtoMaxBulkLen(); n != DefaultProtoMaxBulkLen {
+		t.Errorf("%d != %d", n, DefaultProtoMaxBulkLen)
+	}
+}
@@ -15,31 +15,189 @@
 package redis
 
 import (
+	"fmt"
+	"os"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/cybergarage/go-authenticator/auth/tls"
 )
 
 const (
-	portConfig    = "port"
-	requirePass   = "requirepass"
-	tlsPortConfig = "tls-port"
-	tlsCertFile   = "tls-cert-file"
-	tlsKeyFile    = "tls-key-file"
-	tlsCACertFile = "tls-ca-cert-file"
+	portConfig                 = "port"
+	requirePass                = "requirepass"
+	tlsPortConfig              = "tls-port"
+	tlsCertFile                = "tls-cert-file"
+	tlsKeyFile                 = "tls-key-file"
+	tlsCACertFile              = "tls-ca-cert-file"
+	tlsReloadInterval          = "tls-reload-interval"
+	tlsAutoDetect              = "tls-auto-detect"
+	tlsHandshakeTimeout        = "tls-handshake-timeout"
+	tlsRequiredForRemote       = "tls-required-for-remote"
+	maxClientsConfig           = "maxclients"
+	maxClientsPerIPConfig      = "maxclients-per-ip"
+	acceptRateLimitConfig      = "accept-rate-limit"
+	timeoutConfig              = "timeout"
+	commandTimeoutConfig       = "command-timeout"
+	tcpKeepAliveConfig         = "tcp-keepalive"
+	protoMaxBulkLenConfig      = "proto-max-bulk-len"
+	protoMaxMultiBulkLenConfig = "proto-max-multibulk-len"
+	queryBufLimitConfig        = "client-query-buffer-limit"
+	outputBufLimitConfig       = "client-output-buffer-limit"
+	shutdownTimeoutConfig      = "shutdown-timeout"
+	shutdownAbortWindowConfig  = "shutdown-abort-window"
+	unixSocketConfig           = "unixsocket"
+	unixSocketPermConfig       = "unixsocketperm"
+	bindConfig                 = "bind"
+	protectedModeConfig        = "protected-mode"
+	proxyProtocolConfig        = "proxy-protocol"
+	proxyTrustedConfig         = "proxy-protocol-trusted-sources"
 )
 
+// configValidators validates the parameters which are changed by CONFIG SET command.
+var configValidators = map[string]func(string) error{
+	maxClientsConfig:      validateNonNegativeInteger,
+	timeoutConfig:         validateNonNegativeInteger,
+	protoMaxBulkLenConfig: validateMemorySize,
+	queryBufLimitConfig:   validateMemorySize,
+	outputBufLimitConfig:  validateOutputBufferLimits,
+}
+
+// validateConfig returns ErrInvalidArgument if the specified parameter is invalid.
+func validateConfig(key string, param string) error {
+	validator, ok := configValidators[strings.ToLower(key)]
+	if !ok {
+		return nil
+	}
+
+	err := validator(param)
+	if err != nil {
+		return fmt.Errorf("%w (%s %s)", ErrInvalidArgument, key, err)
+	}
+
+	return nil
+}
+
+// validateNonNegativeInteger returns an error if the specified parameter is not a non-negative integer.
+func validateNonNegativeInteger(param string) error {
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		return err
+	}
+
+	if n < 0 {
+		return fmt.Errorf("%w integer (%d)", ErrInvalid, n)
+	}
+
+	return nil
+}
+
+// validateMemorySize returns an error if the specified parameter is not a memory size.
+func validateMemorySize(param string) error {
+	_, err := parseMemorySize(param)
+	return err
+}
+
+// validateOutputBufferLimits returns an error if the specified parameter is not output buffer limits.
+func validateOutputBufferLimits(param string) error {
+	_, err := parseOutputBufferLimits(param)
+	return err
+}
+
 // serverConfig is a configuration for the Redis server.
 type serverConfig struct {
 	*configMap
 	tls.CertConfig
+	limitsMutex *sync.Mutex
+	limits      *atomic.Pointer[configLimits]
+}
+
+// configLimits represents the parsed limits which are referred on every request and response.
+type configLimits struct {
+	protoMaxBulkLen      int
+	protoMaxMultiBulkLen int
+	queryBufLimit        int
+	outputBufLimits      []OutputBufferLimit
 }
 
 // newDefaultServerConfig returns a default server configuration.
 func newDefaultServerConfig() *serverConfig {
-	return &serverConfig{
-		configMap:  newConfig(),
-		CertConfig: tls.NewCertConfig()}
+	cfg := &serverConfig{
+		configMap:   newConfig(),
+		CertConfig:  tls.NewCertConfig(),
+		limitsMutex: &sync.Mutex{},
+		limits:      &atomic.Pointer[configLimits]{},
+	}
+	cfg.refreshLimits()
+
+	return cfg
+}
+
+// SetConfig sets a specified parameter, and refreshes the parsed limits.
+func (cfg *serverConfig) SetConfig(key string, params string) {
+	cfg.limitsMutex.Lock()
+	defer cfg.limitsMutex.Unlock()
+
+	cfg.configMap.SetConfig(key, params)
+	cfg.refreshLimits()
+}
+
+// AppendConfig appends a specified parameter, and refreshes the parsed limits.
+func (cfg *serverConfig) AppendConfig(key string, params string) {
+	cfg.limitsMutex.Lock()
+	defer cfg.limitsMutex.Unlock()
+
+	cfg.configMap.AppendConfig(key, params)
+	cfg.refreshLimits()
+}
+
+// RemoveConfig removes the specified parameter, and refreshes the parsed limits.
+func (cfg *serverConfig) RemoveConfig(key string) {
+	cfg.limitsMutex.Lock()
+	defer cfg.limitsMutex.Unlock()
+
+	cfg.configMap.RemoveConfig(key)
+	cfg.refreshLimits()
+}
+
+// refreshLimits parses the limits not to parse them on every request and response.
+func (cfg *serverConfig) refreshLimits() {
+	limits := &configLimits{
+		protoMaxBulkLen:      DefaultProtoMaxBulkLen,
+		protoMaxMultiBulkLen: DefaultProtoMaxMultiBulkLen,
+		queryBufLimit:        DefaultClientQueryBufferLimit,
+		outputBufLimits:      newDefaultOutputBufferLimits(),
+	}
+
+	if n, ok := cfg.ConfigMemorySize(protoMaxBulkLenConfig); ok {
+		limits.protoMaxBulkLen = n
+	}
+
+	if n, ok := cfg.ConfigInteger(protoMaxMultiBulkLenConfig); ok {
+		limits.protoMaxMultiBulkLen = n
+	}
+
+	if n, ok := cfg.ConfigMemorySize(queryBufLimitConfig); ok {
+		limits.queryBufLimit = n
+	}
+
+	if str, ok := cfg.ConfigString(outputBufLimitConfig); ok {
+		cfgLimits, err := parseOutputBufferLimits(str)
+		if err == nil {
+			for _, cfgLimit := range cfgLimits {
+				for n, limit := range limits.outputBufLimits {
+					if limit.Class == cfgLimit.Class {
+						limits.outputBufLimits[n] = cfgLimit
+					}
+				}
+			}
+		}
+	}
+
+	cfg.limits.Store(limits)
 }
 
 // SetPort sets a listen port number.
@@ -67,6 +225,61 @@ func (cfg *serverConfig) IsPortEnabled() bool {
 	return (0 < port)
 }
 
+// SetBind sets addresses to listen on, an address prefixed with "-" is skipped if it is unavailable.
+func (cfg *serverConfig) SetBind(addrs ...string) {
+	cfg.SetConfig(bindConfig, strings.Join(addrs, ConfigSep))
+}
+
+// Bind returns addresses to listen on.
+func (cfg *serverConfig) Bind() []string {
+	addrs, ok := cfg.ConfigString(bindConfig)
+	if !ok {
+		return []string{}
+	}
+
+	return strings.Fields(addrs)
+}
+
+// SetUnixSocket sets a path of the Unix domain socket to listen on.
+func (cfg *serverConfig) SetUnixSocket(path string) {
+	cfg.SetConfig(unixSocketConfig, path)
+}
+
+// UnixSocket returns a path of the Unix domain socket to listen on.
+func (cfg *serverConfig) UnixSocket() string {
+	path, ok := cfg.ConfigString(unixSocketConfig)
+	if !ok {
+		return DefaultUnixSocket
+	}
+
+	return path
+}
+
+// IsUnixSocketEnabled returns true if a Unix domain socket is enabled.
+func (cfg *serverConfig) IsUnixSocketEnabled() bool {
+	return 0 < len(cfg.UnixSocket())
+}
+
+// SetUnixSocketPerm sets a file permission of the Unix domain socket.
+func (cfg *serverConfig) SetUnixSocketPerm(perm os.FileMode) {
+	cfg.SetConfig(unixSocketPermConfig, strconv.FormatUint(uint64(perm.Perm()), 8))
+}
+
+// UnixSocketPerm returns a file permission of the Unix domain socket, 0 means the default permission.
+func (cfg *serverConfig) UnixSocketPerm() os.FileMode {
+	str, ok := cfg.ConfigString(unixSocketPermConfig)
+	if !ok {
+		return DefaultUnixSocketPerm
+	}
+
+	perm, err := strconv.ParseUint(str, 8, 32)
+	if err != nil {
+		return DefaultUnixSocketPerm
+	}
+
+	return os.FileMode(perm).Perm()
+}
+
 // SetTLSPort sets a listen port number for TLS.
 func (cfg *serverConfig) SetTLSPort(port int) {
 	cfg.SetConfig(tlsPortConfig, strconv.Itoa(port))
@@ -92,6 +305,162 @@ func (cfg *serverConfig) IsTLSPortEnabled() bool {
 	return (0 < port)
 }
 
+// SetServerCertFile loads a SSL server certificate file and sets it.
+func (cfg *serverConfig) SetServerCertFile(file string) error {
+	err := cfg.CertConfig.SetServerCertFile(file)
+	if err != nil {
+		return err
+	}
+
+	cfg.SetConfig(tlsCertFile, file)
+
+	return nil
+}
+
+// SetServerKeyFile loads a SSL server key file and sets it.
+func (cfg *serverConfig) SetServerKeyFile(file string) error {
+	err := cfg.CertConfig.SetServerKeyFile(file)
+	if err != nil {
+		return err
+	}
+
+	cfg.SetConfig(tlsKeyFile, file)
+
+	return nil
+}
+
+// SetRootCertFiles loads SSL root certificate files and sets them.
+func (cfg *serverConfig) SetRootCertFiles(files ...string) error {
+	err := cfg.CertConfig.SetRootCertFiles(files...)
+	if err != nil {
+		return err
+	}
+
+	cfg.SetConfig(tlsCACertFile, strings.Join(files, ConfigSep))
+
+	return nil
+}
+
+// TLSCertFile returns a loaded SSL server certificate file.
+func (cfg *serverConfig) TLSCertFile() string {
+	file, _ := cfg.ConfigString(tlsCertFile)
+	return file
+}
+
+// TLSKeyFile returns a loaded SSL server key file.
+func (cfg *serverConfig) TLSKeyFile() string {
+	file, _ := cfg.ConfigString(tlsKeyFile)
+	return file
+}
+
+// TLSCACertFiles returns loaded SSL root certificate files.
+func (cfg *serverConfig) TLSCACertFiles() []string {
+	files, ok := cfg.ConfigString(tlsCACertFile)
+	if !ok {
+		return []string{}
+	}
+
+	return strings.Fields(files)
+}
+
+// SetTLSHandshakeTimeout sets the duration to wait for TLS handshakes to complete, 0 means no timeout.
+func (cfg *serverConfig) SetTLSHandshakeTimeout(d time.Duration) {
+	cfg.SetConfig(tlsHandshakeTimeout, strconv.Itoa(int(d/time.Second)))
+}
+
+// TLSHandshakeTimeout returns the duration to wait for TLS handshakes to complete.
+func (cfg *serverConfig) TLSHandshakeTimeout() time.Duration {
+	secs, ok := cfg.ConfigInteger(tlsHandshakeTimeout)
+	if !ok {
+		return DefaultTLSHandshakeTimeout
+	}
+
+	return time.Duration(secs) * time.Second
+}
+
+// SetTLSAutoDetect sets whether to detect TLS connections on the listen port by peeking the first bytes.
+func (cfg *serverConfig) SetTLSAutoDetect(enabled bool) {
+	if enabled {
+		cfg.SetConfig(tlsAutoDetect, ConfigYes)
+	} else {
+		cfg.SetConfig(tlsAutoDetect, ConfigNo)
+	}
+}
+
+// IsTLSAutoDetectEnabled returns true if TLS connections are detected on the listen port.
+func (cfg *serverConfig) IsTLSAutoDetectEnabled() bool {
+	enabled, ok := cfg.ConfigBool(tlsAutoDetect)
+	if !ok {
+		return DefaultTLSAutoDetect
+	}
+
+	return enabled
+}
+
+// SetTLSRequiredForRemote sets whether to refuse plaintext connections from non-loopback clients on the auto-detecting port.
+func (cfg *serverConfig) SetTLSRequiredForRemote(required bool) {
+	if required {
+		cfg.SetConfig(tlsRequiredForRemote, ConfigYes)
+	} else {
+		cfg.SetConfig(tlsRequiredForRemote, ConfigNo)
+	}
+}
+
+// IsTLSRequiredForRemote returns true if plaintext connections from non-loopback clients are refused on the auto-detecting port.
+func (cfg *serverConfig) IsTLSRequiredForRemote() bool {
+	required, ok := cfg.ConfigBool(tlsRequiredForRemote)
+	if !ok {
+		return DefaultTLSRequiredForRemote
+	}
+
+	return required
+}
+
+// SetTLSReloadInterval sets the interval to check the certificate files for changes, 0 means disabled.
+func (cfg *serverConfig) SetTLSReloadInterval(d time.Duration) {
+	cfg.SetConfig(tlsReloadInterval, strconv.Itoa(int(d/time.Second)))
+}
+
+// TLSReloadInterval returns the interval to check the certificate files for changes.
+func (cfg *serverConfig) TLSReloadInterval() time.Duration {
+	secs, ok := cfg.ConfigInteger(tlsReloadInterval)
+	if !ok {
+		return DefaultTLSReloadInterval
+	}
+
+	return time.Duration(secs) * time.Second
+}
+
+// SetShutdownTimeout sets the duration to wait for connections to be closed by SHUTDOWN command.
+func (cfg *serverConfig) SetShutdownTimeout(d time.Duration) {
+	cfg.SetConfig(shutdownTimeoutConfig, strconv.Itoa(int(d/time.Second)))
+}
+
+// ShutdownTimeout returns the duration to wait for connections to be closed by SHUTDOWN command.
+func (cfg *serverConfig) ShutdownTimeout() time.Duration {
+	secs, ok := cfg.ConfigInteger(shutdownTimeoutConfig)
+	if !ok {
+		return DefaultShutdownTimeout
+	}
+
+	return time.Duration(secs) * time.Second
+}
+
+// SetShutdownAbortWindow sets the duration to keep serving clients before draining connections, in which SHUTDOWN ABORT can cancel the shutdown.
+func (cfg *serverConfig) SetShutdownAbortWindow(d time.Duration) {
+	cfg.SetConfig(shutdownAbortWindowConfig, strconv.Itoa(int(d/time.Second)))
+}
+
+// ShutdownAbortWindow returns the duration to keep serving clients before draining connections, in which SHUTDOWN ABORT can cancel the shutdown.
+func (cfg *serverConfig) ShutdownAbortWindow() time.Duration {
+	secs, ok := cfg.ConfigInteger(shutdownAbortWindowConfig)
+	if !ok {
+		return DefaultShutdownAbortWindow
+	}
+
+	return time.Duration(secs) * time.Second
+}
+
 // SetRequirePass sets a password.
 func (cfg *serverConfig) SetRequirePass(password string) {
 	cfg.SetConfig(requirePass, password)
@@ -106,3 +475,208 @@ func (cfg *serverConfig) ConfigRequirePass() (string, bool) {
 func (cfg *serverConfig) RemoveRequirePass() {
 	cfg.RemoveConfig(requirePass)
 }
+
+// SetProxyProtocol sets whether to read PROXY protocol headers from accepted connections.
+func (cfg *serverConfig) SetProxyProtocol(enabled bool) {
+	if enabled {
+		cfg.SetConfig(proxyProtocolConfig, ConfigYes)
+	} else {
+		cfg.SetConfig(proxyProtocolConfig, ConfigNo)
+	}
+}
+
+// IsProxyProtocolEnabled returns true if PROXY protocol headers are read from accepted connections.
+func (cfg *serverConfig) IsProxyProtocolEnabled() bool {
+	enabled, ok := cfg.ConfigBool(proxyProtocolConfig)
+	if !ok {
+		return DefaultProxyProtocol
+	}
+
+	return enabled
+}
+
+// SetProxyProtocolTrustedSources sets IP addresses or CIDR blocks of proxies allowed to send PROXY protocol headers.
+func (cfg *serverConfig) SetProxyProtocolTrustedSources(sources ...string) {
+	cfg.SetConfig(proxyTrustedConfig, strings.Join(sources, ConfigSep))
+}
+
+// ProxyProtocolTrustedSources returns IP addresses or CIDR blocks of proxies allowed to send PROXY protocol headers, only loopback proxies are allowed if empty.
+func (cfg *serverConfig) ProxyProtocolTrustedSources() []string {
+	sources, ok := cfg.ConfigString(proxyTrustedConfig)
+	if !ok {
+		return []string{}
+	}
+
+	return strings.Fields(sources)
+}
+
+// SetProtectedMode sets whether to refuse non-loopback clients when no password or credentials are configured.
+func (cfg *serverConfig) SetProtectedMode(enabled bool) {
+	if enabled {
+		cfg.SetConfig(protectedModeConfig, ConfigYes)
+	} else {
+		cfg.SetConfig(protectedModeConfig, ConfigNo)
+	}
+}
+
+// IsProtectedModeEnabled returns true if the protected mode is enabled.
+func (cfg *serverConfig) IsProtectedModeEnabled() bool {
+	enabled, ok := cfg.ConfigBool(protectedModeConfig)
+	if !ok {
+		return DefaultProtectedMode
+	}
+
+	return enabled
+}
+
+// SetMaxClients sets the maximum number of connected clients.
+func (cfg *serverConfig) SetMaxClients(n int) {
+	cfg.SetConfig(maxClientsConfig, strconv.Itoa(n))
+}
+
+// MaxClients returns the maximum number of connected clients.
+func (cfg *serverConfig) MaxClients() int {
+	n, ok := cfg.ConfigInteger(maxClientsConfig)
+	if !ok {
+		return DefaultMaxClients
+	}
+
+	return n
+}
+
+// SetMaxClientsPerIP sets the maximum number of connected clients from the same IP address.
+func (cfg *serverConfig) SetMaxClientsPerIP(n int) {
+	cfg.SetConfig(maxClientsPerIPConfig, strconv.Itoa(n))
+}
+
+// MaxClientsPerIP returns the maximum number of connected clients from the same IP address.
+func (cfg *serverConfig) MaxClientsPerIP() int {
+	n, ok := cfg.ConfigInteger(maxClientsPerIPConfig)
+	if !ok {
+		return DefaultMaxClientsPerIP
+	}
+
+	return n
+}
+
+// SetAcceptRateLimit sets the maximum number of accepted connections per second.
+func (cfg *serverConfig) SetAcceptRateLimit(n int) {
+	cfg.SetConfig(acceptRateLimitConfig, strconv.Itoa(n))
+}
+
+// AcceptRateLimit returns the maximum number of accepted connections per second.
+func (cfg *serverConfig) AcceptRateLimit() int {
+	n, ok := cfg.ConfigInteger(acceptRateLimitConfig)
+	if !ok {
+		return DefaultAcceptRateLimit
+	}
+
+	return n
+}
+
+// SetIdleTimeout sets the duration to close idle clients, 0 means disabled.
+func (cfg *serverConfig) SetIdleTimeout(d time.Duration) {
+	cfg.SetConfig(timeoutConfig, strconv.Itoa(int(d/time.Second)))
+}
+
+// IdleTimeout returns the duration to close idle clients.
+func (cfg *serverConfig) IdleTimeout() time.Duration {
+	secs, ok := cfg.ConfigInteger(timeoutConfig)
+	if !ok {
+		return DefaultIdleTimeout
+	}
+
+	return time.Duration(secs) * time.Second
+}
+
+// SetCommandTimeout sets the duration to cancel the context of running commands, 0 means disabled.
+func (cfg *serverConfig) SetCommandTimeout(d time.Duration) {
+	cfg.SetConfig(commandTimeoutConfig, strconv.FormatInt(d.Milliseconds(), 10))
+}
+
+// CommandTimeout returns the duration to cancel the context of running commands.
+func (cfg *serverConfig) CommandTimeout() time.Duration {
+	msecs, ok := cfg.ConfigInteger(commandTimeoutConfig)
+	if !ok {
+		return DefaultCommandTimeout
+	}
+
+	return time.Duration(msecs) * time.Millisecond
+}
+
+// SetTCPKeepAlive sets the period of TCP keepalive probes, 0 means disabled.
+func (cfg *serverConfig) SetTCPKeepAlive(d time.Duration) {
+	cfg.SetConfig(tcpKeepAliveConfig, strconv.Itoa(int(d/time.Second)))
+}
+
+// TCPKeepAlive returns the period of TCP keepalive probes.
+func (cfg *serverConfig) TCPKeepAlive() time.Duration {
+	secs, ok := cfg.ConfigInteger(tcpKeepAliveConfig)
+	if !ok {
+		return DefaultTCPKeepAlive
+	}
+
+	return time.Duration(secs) * time.Second
+}
+
+// SetProtoMaxBulkLen sets the maximum length of a request bulk string.
+func (cfg *serverConfig) SetProtoMaxBulkLen(n int) {
+	cfg.SetConfig(protoMaxBulkLenConfig, strconv.Itoa(n))
+}
+
+// ProtoMaxBulkLen returns the maximum length of a request bulk string.
+func (cfg *serverConfig) ProtoMaxBulkLen() int {
+	return cfg.limits.Load().protoMaxBulkLen
+}
+
+// SetProtoMaxMultiBulkLen sets the maximum number of elements of a request array.
+func (cfg *serverConfig) SetProtoMaxMultiBulkLen(n int) {
+	cfg.SetConfig(protoMaxMultiBulkLenConfig, strconv.Itoa(n))
+}
+
+// ProtoMaxMultiBulkLen returns the maximum number of elements of a request array.
+func (cfg *serverConfig) ProtoMaxMultiBulkLen() int {
+	return cfg.limits.Load().protoMaxMultiBulkLen
+}
+
+// SetClientQueryBufferLimit sets the maximum size of a client request.
+func (cfg *serverConfig) SetClientQueryBufferLimit(n int) {
+	cfg.SetConfig(queryBufLimitConfig, strconv.Itoa(n))
+}
+
+// ClientQueryBufferLimit returns the maximum size of a client request.
+func (cfg *serverConfig) ClientQueryBufferLimit() int {
+	return cfg.limits.Load().queryBufLimit
+}
+
+// SetClientOutputBufferLimit sets the output buffer limit for the client class of the specified limit.
+func (cfg *serverConfig) SetClientOutputBufferLimit(limit OutputBufferLimit) {
+	limits := cfg.clientOutputBufferLimits()
+	strs := make([]string, 0, len(limits))
+
+	for _, l := range limits {
+		if l.Class == limit.Class {
+			l = limit
+		}
+
+		strs = append(strs, l.String())
+	}
+
+	cfg.SetConfig(outputBufLimitConfig, strings.Join(strs, ConfigSep))
+}
+
+// ClientOutputBufferLimit returns the output buffer limit for the specified client class.
+func (cfg *serverConfig) ClientOutputBufferLimit(class ClientClass) OutputBufferLimit {
+	for _, limit := range cfg.clientOutputBufferLimits() {
+		if limit.Class == class {
+			return limit
+		}
+	}
+
+	return OutputBufferLimit{Class: class, HardLimit: 0, SoftLimit: 0, SoftDuration: 0}
+}
+
+// clientOutputBufferLimits returns the output buffer limits for all client classes.
+func (cfg *serverConfig) clientOutputBufferLimits() []OutputBufferLimit {
+	return cfg.limits.Load().outputBufLimits
+}
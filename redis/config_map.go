@@ -15,33 +15,45 @@
 package redis
 
 import (
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 const (
 	ConfigSep = " "
+	ConfigYes = "yes"
+	ConfigNo  = "no"
 )
 
 // configMap represents a server configuration.
 type configMap struct {
 	params map[string]string
+	mutex  *sync.RWMutex
 }
 
 // newConfig returns a new configuration.
 func newConfig() *configMap {
 	return &configMap{
 		params: map[string]string{},
+		mutex:  &sync.RWMutex{},
 	}
 }
 
 // SetConfig sets a specified parameter.
 func (cfg *configMap) SetConfig(key string, params string) {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+
 	cfg.params[key] = params
 }
 
 // AppendConfig appends a specified parameter.
 func (cfg *configMap) AppendConfig(key string, params string) {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+
 	currParams, ok := cfg.params[key]
 	if !ok {
 		cfg.params[key] = params
@@ -51,15 +63,33 @@ func (cfg *configMap) AppendConfig(key string, params string) {
 	cfg.params[key] = strings.Join([]string{currParams, params}, ConfigSep)
 }
 
+// ConfigKeys returns the sorted keys of all parameters.
+func (cfg *configMap) ConfigKeys() []string {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+
+	keys := make([]string, 0, len(cfg.params))
+	for key := range cfg.params {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
 // ConfigString return the specified parameter.
 func (cfg *configMap) ConfigString(key string) (string, bool) {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+
 	params, ok := cfg.params[key]
 	return params, ok
 }
 
 // ConfigInteger returns the specified parameter as an integer.
 func (cfg *configMap) ConfigInteger(key string) (int, bool) {
-	params, ok := cfg.params[key]
+	params, ok := cfg.ConfigString(key)
 	if !ok {
 		return 0, false
 	}
@@ -72,7 +102,42 @@ func (cfg *configMap) ConfigInteger(key string) (int, bool) {
 	return v, true
 }
 
+// ConfigBool returns the specified parameter as a boolean such as "yes" or "no".
+func (cfg *configMap) ConfigBool(key string) (bool, bool) {
+	params, ok := cfg.ConfigString(key)
+	if !ok {
+		return false, false
+	}
+
+	switch strings.ToLower(params) {
+	case ConfigYes:
+		return true, true
+	case ConfigNo:
+		return false, true
+	}
+
+	return false, false
+}
+
+// ConfigMemorySize returns the specified parameter as a memory size such as "1gb".
+func (cfg *configMap) ConfigMemorySize(key string) (int, bool) {
+	params, ok := cfg.ConfigString(key)
+	if !ok {
+		return 0, false
+	}
+
+	v, err := parseMemorySize(params)
+	if err != nil {
+		return 0, false
+	}
+
+	return v, true
+}
+
 // RemoveConfig removes the specified parameter.
 func (cfg *configMap) RemoveConfig(key string) {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+
 	delete(cfg.params, key)
 }
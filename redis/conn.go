@@ -15,11 +15,14 @@
 package redis
 
 import (
+	"context"
 	"crypto/tls"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/cybergarage/go-redis/redis/proxy"
 	"github.com/cybergarage/go-tracing/tracer"
 	"github.com/google/uuid"
 )
@@ -27,48 +30,63 @@ import (
 // Conn represents a database connection.
 type Conn struct {
 	net.Conn
-	isClosed  bool
+	isClosed  atomic.Bool
 	id        DatabaseID
 	authrized bool
 	sync.Map
-	ts time.Time
-	tracer.Context
-	tlsConn  *tls.Conn
-	username string
-	password string
-	uuid     uuid.UUID
+	ts        time.Time
+	spanCtx   tracer.Context
+	tlsConn   *tls.Conn
+	username  string
+	password  string
+	uuid      uuid.UUID
+	class     ClientClass
+	ctx       context.Context
+	cancel    context.CancelCauseFunc
+	ctxMutex  *sync.Mutex
+	cmdCtx    context.Context
+	cmdCancel context.CancelCauseFunc
+	watchCh   chan struct{}
+	peeked    []byte
+	peekErr   error
 }
 
 func newConnWith(conn net.Conn, tlsConn *tls.Conn) *Conn {
+	ctx, cancel := context.WithCancelCause(context.Background())
+
 	return &Conn{
 		Conn:      conn,
-		isClosed:  false,
+		isClosed:  atomic.Bool{},
 		authrized: false,
 		id:        0,
 		Map:       sync.Map{},
 		ts:        time.Now(),
-		Context:   nil,
+		spanCtx:   nil,
 		tlsConn:   tlsConn,
 		username:  "",
 		password:  "",
 		uuid:      uuid.New(),
+		class:     NormalClient,
+		ctx:       ctx,
+		cancel:    cancel,
+		ctxMutex:  &sync.Mutex{},
+		cmdCtx:    nil,
+		cmdCancel: nil,
+		watchCh:   nil,
+		peeked:    nil,
+		peekErr:   nil,
 	}
 }
 
-// Close closes the connection.
+// Close closes the connection, and cancels the connection context.
 func (conn *Conn) Close() error {
-	if conn.isClosed {
+	if !conn.isClosed.CompareAndSwap(false, true) {
 		return nil
 	}
 
-	err := conn.Conn.Close()
-	if err != nil {
-		return err
-	}
-
-	conn.isClosed = true
+	conn.cancel(ErrConnClosed)
 
-	return nil
+	return conn.Conn.Close()
 }
 
 // SetDatabase sets the selected database number to the connection.
@@ -118,12 +136,39 @@ func (conn *Conn) Timestamp() time.Time {
 
 // SetSpanContext sets the span context to the connection.
 func (conn *Conn) SetSpanContext(span tracer.Context) {
-	conn.Context = span
+	conn.spanCtx = span
 }
 
 // SpanContext returns the span context of the connection.
 func (conn *Conn) SpanContext() tracer.Context {
-	return conn.Context
+	return conn.spanCtx
+}
+
+// Span returns the current top tracer span of the span context.
+func (conn *Conn) Span() tracer.Span {
+	if conn.spanCtx == nil {
+		return &tracer.NullSpan
+	}
+
+	return conn.spanCtx.Span()
+}
+
+// StartSpan starts a new child tracer span of the span context.
+func (conn *Conn) StartSpan(name string) bool {
+	if conn.spanCtx == nil {
+		return false
+	}
+
+	return conn.spanCtx.StartSpan(name)
+}
+
+// FinishSpan ends the current top tracer span of the span context.
+func (conn *Conn) FinishSpan() bool {
+	if conn.spanCtx == nil {
+		return false
+	}
+
+	return conn.spanCtx.FinishSpan()
 }
 
 // IsTLSConnection return true if the connection is enabled TLS.
@@ -142,7 +187,37 @@ func (conn *Conn) TLSConnectionState() (*tls.ConnectionState, bool) {
 	return &tlsState, true
 }
 
+// RemoteAddr returns the client address, which is the original client address reported by the proxy if the connection is accepted via PROXY protocol.
+func (conn *Conn) RemoteAddr() net.Addr {
+	return conn.Conn.RemoteAddr()
+}
+
+// ProxyAddr returns the proxy address and true if the connection is accepted via PROXY protocol.
+func (conn *Conn) ProxyAddr() (net.Addr, bool) {
+	c := conn.Conn
+	if conn.tlsConn != nil {
+		c = conn.tlsConn.NetConn()
+	}
+
+	proxyConn, ok := c.(*proxy.Conn)
+	if !ok {
+		return nil, false
+	}
+
+	return proxyConn.ProxyAddr(), true
+}
+
 // UUID returns the UUID of the connection.
 func (conn *Conn) UUID() uuid.UUID {
 	return conn.uuid
 }
+
+// SetClientClass sets the client class of the connection for the output buffer limits.
+func (conn *Conn) SetClientClass(class ClientClass) {
+	conn.class = class
+}
+
+// ClientClass returns the client class of the connection.
+func (conn *Conn) ClientClass() ClientClass {
+	return conn.class
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+// Context returns the context of the current command, or the connection context outside of commands.
+// The command context carries the tracing span of the command, and is cancelled when the client disconnects,
+// the connection is closed by the server such as Stop, or the command timeout expires.
+func (conn *Conn) Context() context.Context {
+	conn.ctxMutex.Lock()
+	defer conn.ctxMutex.Unlock()
+
+	if conn.cmdCtx == nil {
+		return conn.ctx
+	}
+
+	conn.watchDisconnect()
+
+	return conn.cmdCtx
+}
+
+// startCommand starts a command context derived from the specified parent context such as a tracing span context.
+// It returns false if the command context has been already started by the outer command such as sugar commands.
+func (conn *Conn) startCommand(parent context.Context, timeout time.Duration) bool {
+	conn.ctxMutex.Lock()
+	defer conn.ctxMutex.Unlock()
+
+	if conn.cmdCtx != nil {
+		return false
+	}
+
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	ctx, cancel := context.WithCancelCause(parent)
+	stop := context.AfterFunc(conn.ctx, func() {
+		cancel(context.Cause(conn.ctx))
+	})
+
+	cmdCtx := ctx
+	cancelTimeout := context.CancelFunc(func() {})
+
+	if 0 < timeout {
+		cmdCtx, cancelTimeout = context.WithTimeoutCause(ctx, timeout, ErrCommandTimeout)
+	}
+
+	conn.cmdCtx = cmdCtx
+	conn.cmdCancel = func(cause error) {
+		stop()
+		cancelTimeout()
+		cancel(cause)
+	}
+
+	return true
+}
+
+// finishCommand cancels the current command context, and stops watching the client disconnection.
+func (conn *Conn) finishCommand() {
+	conn.ctxMutex.Lock()
+	defer conn.ctxMutex.Unlock()
+
+	if conn.watchCh != nil {
+		// Interrupts the pending read, and the read deadline is reset before reading the next request.
+		conn.Conn.SetReadDeadline(time.Now())
+		<-conn.watchCh
+		conn.watchCh = nil
+	}
+
+	if conn.cmdCancel != nil {
+		conn.cmdCancel(context.Canceled)
+	}
+
+	conn.cmdCtx = nil
+	conn.cmdCancel = nil
+}
+
+// watchDisconnect starts reading the connection in the background to cancel the command context when the client disconnects.
+// The read data is kept and returned by Read for the next request.
+func (conn *Conn) watchDisconnect() {
+	if conn.watchCh != nil || 0 < len(conn.peeked) || conn.peekErr != nil {
+		return
+	}
+
+	watchCh := make(chan struct{})
+	conn.watchCh = watchCh
+	cancel := conn.cmdCancel
+
+	go func() {
+		defer close(watchCh)
+
+		b := make([]byte, 1)
+
+		n, err := conn.Conn.Read(b)
+		conn.peeked = b[:n]
+
+		if err == nil || isIdleTimeoutError(err) {
+			return
+		}
+
+		conn.peekErr = err
+		cancel(ErrConnClosed)
+	}()
+}
+
+// Read reads data from the connection including the data read while watching the client disconnection.
+func (conn *Conn) Read(b []byte) (int, error) {
+	if 0 < len(conn.peeked) {
+		n := copy(b, conn.peeked)
+		conn.peeked = conn.peeked[n:]
+
+		return n, nil
+	}
+
+	if conn.peekErr != nil {
+		return 0, conn.peekErr
+	}
+
+	return conn.Conn.Read(b)
+}
@@ -16,6 +16,7 @@ package redis
 
 import (
 	"errors"
+	"net"
 	"sync"
 
 	"github.com/google/uuid"
@@ -23,15 +24,17 @@ import (
 
 // ConnManager represents a connection map.
 type ConnManager struct {
-	m     map[uuid.UUID]*Conn
-	mutex *sync.RWMutex
+	m      map[uuid.UUID]*Conn
+	hostsN map[string]int
+	mutex  *sync.RWMutex
 }
 
 // NewConnManager returns a connection map.
 func NewConnManager() *ConnManager {
 	return &ConnManager{
-		m:     map[uuid.UUID]*Conn{},
-		mutex: &sync.RWMutex{},
+		m:      map[uuid.UUID]*Conn{},
+		hostsN: map[string]int{},
+		mutex:  &sync.RWMutex{},
 	}
 }
 
@@ -40,8 +43,52 @@ func (mgr *ConnManager) AddConn(c *Conn) {
 	mgr.mutex.Lock()
 	defer mgr.mutex.Unlock()
 
+	mgr.addConn(c)
+}
+
+// AddConnWithLimits adds the specified connection if the number of connections does not exceed the specified limits.
+// A limit less than or equal to zero means unlimited.
+func (mgr *ConnManager) AddConnWithLimits(c *Conn, maxConns int, maxConnsPerHost int) error {
+	mgr.mutex.Lock()
+	defer mgr.mutex.Unlock()
+
+	if 0 < maxConns && maxConns <= len(mgr.m) {
+		return ErrMaxClients
+	}
+
+	if 0 < maxConnsPerHost && maxConnsPerHost <= mgr.hostsN[connHost(c)] {
+		return ErrMaxClientsIP
+	}
+
+	mgr.addConn(c)
+
+	return nil
+}
+
+func (mgr *ConnManager) addConn(c *Conn) {
 	uuid := c.UUID()
+	if _, ok := mgr.m[uuid]; ok {
+		return
+	}
+
 	mgr.m[uuid] = c
+	mgr.hostsN[connHost(c)]++
+}
+
+// NumConns returns the number of the included connections.
+func (mgr *ConnManager) NumConns() int {
+	mgr.mutex.RLock()
+	defer mgr.mutex.RUnlock()
+
+	return len(mgr.m)
+}
+
+// NumConnsByHost returns the number of the included connections from the specified host.
+func (mgr *ConnManager) NumConnsByHost(host string) int {
+	mgr.mutex.RLock()
+	defer mgr.mutex.RUnlock()
+
+	return mgr.hostsN[host]
 }
 
 // Conns returns the included connections.
@@ -72,7 +119,19 @@ func (mgr *ConnManager) RemoveConn(conn *Conn) error {
 	mgr.mutex.Lock()
 	defer mgr.mutex.Unlock()
 
-	delete(mgr.m, conn.UUID())
+	uuid := conn.UUID()
+	if _, ok := mgr.m[uuid]; !ok {
+		return nil
+	}
+
+	delete(mgr.m, uuid)
+
+	host := connHost(conn)
+
+	mgr.hostsN[host]--
+	if mgr.hostsN[host] <= 0 {
+		delete(mgr.hostsN, host)
+	}
 
 	return nil
 }
@@ -111,3 +170,18 @@ func (mgr *ConnManager) Stop() error {
 
 	return nil
 }
+
+// connHost returns the remote host of the specified connection.
+func connHost(conn *Conn) string {
+	addr := conn.RemoteAddr()
+	if addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+
+	return host
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestConnManagerLimits(t *testing.T) {
+	mgr := NewConnManager()
+
+	newConn := func() *Conn {
+		c, _ := net.Pipe()
+		return newConnWith(c, nil)
+	}
+
+	conns := []*Conn{}
+	for range 2 {
+		conn := newConn()
+		if err := mgr.AddConnWithLimits(conn, 2, 0); err != nil {
+			t.Error(err)
+			return
+		}
+		conns = append(conns, conn)
+	}
+
+	if err := mgr.AddConnWithLimits(newConn(), 2, 0); !errors.Is(err, ErrMaxClients) {
+		t.Errorf("%v != %v", err, ErrMaxClients)
+	}
+
+	if err := mgr.AddConnWithLimits(newConn(), 0, 2); !errors.Is(err, ErrMaxClientsIP) {
+		t.Errorf("%v != %v", err, ErrMaxClientsIP)
+	}
+
+	for _, conn := range conns {
+		if err := mgr.RemoveConn(conn); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if n := mgr.NumConns(); n != 0 {
+		t.Errorf("%d != %d", n, 0)
+	}
+
+	if err := mgr.AddConnWithLimits(newConn(), 2, 2); err != nil {
+		t.Error(err)
+	}
+}
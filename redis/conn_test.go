@@ -0,0 +1,147 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func testConnContextDone(t *testing.T, ctx context.Context, expected error) {
+	t.Helper()
+
+	select {
+	case <-ctx.Done():
+		if cause := context.Cause(ctx); !errors.Is(cause, expected) {
+			t.Errorf("%v != %v", cause, expected)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("context has not been cancelled")
+	}
+}
+
+func TestConnContextDisconnect(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	conn := newConnWith(serverConn, nil)
+
+	conn.startCommand(context.Background(), 0)
+	ctx := conn.Context()
+
+	clientConn.Close()
+
+	testConnContextDone(t, ctx, ErrConnClosed)
+
+	conn.finishCommand()
+}
+
+func TestConnContextNested(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+
+	conn := newConnWith(serverConn, nil)
+	defer conn.Close()
+
+	if !conn.startCommand(context.Background(), 0) {
+		t.Error("command context has not been started")
+		return
+	}
+
+	ctx := conn.Context()
+
+	if conn.startCommand(context.Background(), 0) {
+		t.Error("nested command context has been started")
+	}
+
+	if conn.Context() != ctx {
+		t.Error("command context has been replaced")
+	}
+
+	conn.finishCommand()
+}
+
+func TestConnContextTimeout(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+
+	conn := newConnWith(serverConn, nil)
+	defer conn.Close()
+
+	conn.startCommand(context.Background(), 10*time.Millisecond)
+
+	testConnContextDone(t, conn.Context(), ErrCommandTimeout)
+
+	conn.finishCommand()
+}
+
+func TestConnContextClose(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+
+	conn := newConnWith(serverConn, nil)
+
+	conn.startCommand(context.Background(), 0)
+	ctx := conn.Context()
+
+	conn.Close()
+
+	testConnContextDone(t, ctx, ErrConnClosed)
+	testConnContextDone(t, conn.Context(), ErrConnClosed)
+}
+
+func TestConnContextPipeline(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+
+	conn := newConnWith(serverConn, nil)
+	defer conn.Close()
+
+	conn.startCommand(context.Background(), 0)
+	ctx := conn.Context()
+
+	// The next request is sent while the current command is running.
+	req := "*1\r\n$4\r\nPING\r\n"
+
+	go clientConn.Write([]byte(req))
+
+	time.Sleep(10 * time.Millisecond)
+
+	conn.finishCommand()
+
+	if cause := context.Cause(ctx); !errors.Is(cause, context.Canceled) {
+		t.Errorf("%v != %v", cause, context.Canceled)
+	}
+
+	err := conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	buf := make([]byte, len(req))
+
+	_, err = io.ReadFull(conn, buf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if string(buf) != req {
+		t.Errorf("%q != %q", buf, req)
+	}
+}
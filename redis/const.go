@@ -14,6 +14,11 @@
 
 package redis
 
+import (
+	"os"
+	"time"
+)
+
 const (
 	// PackageName is the package name.
 	PackageName = "go-redis"
@@ -23,6 +28,52 @@ const (
 	DefaultPort = 6379
 	// DefaultTLSPort is the default TLS port number.
 	DefaultTLSPort = 0
+	// DefaultTLSReloadInterval is the default interval to check the certificate files for changes, 0 means disabled.
+	DefaultTLSReloadInterval = time.Duration(0)
+	// DefaultTLSAutoDetect is the default setting to detect TLS connections on the listen port.
+	DefaultTLSAutoDetect = false
+	// DefaultTLSRequiredForRemote is the default setting to refuse plaintext connections from non-loopback clients on the auto-detecting port.
+	DefaultTLSRequiredForRemote = false
+	// DefaultTLSHandshakeTimeout is the default duration to wait for TLS handshakes to complete.
+	DefaultTLSHandshakeTimeout = 10 * time.Second
+	// DefaultTLSDetectTimeout is the default duration to wait for the first bytes to detect TLS connections.
+	DefaultTLSDetectTimeout = 5 * time.Second
+	// DefaultUnixSocket is the default path of the Unix domain socket, an empty path means disabled.
+	DefaultUnixSocket = ""
+	// DefaultUnixSocketPerm is the default file permission of the Unix domain socket, 0 means the default permission.
+	DefaultUnixSocketPerm = os.FileMode(0)
+	// DefaultProtectedMode is the default protected mode to refuse non-loopback clients without passwords.
+	DefaultProtectedMode = true
+	// DefaultProxyProtocol is the default setting to read PROXY protocol headers.
+	DefaultProxyProtocol = false
+	// DefaultProxyHeaderTimeout is the default duration to wait for PROXY protocol headers.
+	DefaultProxyHeaderTimeout = 5 * time.Second
+	// DefaultMaxClients is the default maximum number of connected clients.
+	DefaultMaxClients = 10000
+	// DefaultMaxClientsPerIP is the default maximum number of connected clients from the same IP address, 0 means unlimited.
+	DefaultMaxClientsPerIP = 0
+	// DefaultAcceptRateLimit is the default maximum number of accepted connections per second, 0 means unlimited.
+	DefaultAcceptRateLimit = 0
+	// DefaultIdleTimeout is the default duration to close idle clients, 0 means disabled.
+	DefaultIdleTimeout = time.Duration(0)
+	// DefaultCommandTimeout is the default duration to cancel the context of running commands, 0 means disabled.
+	DefaultCommandTimeout = time.Duration(0)
+	// DefaultTCPKeepAlive is the default period of TCP keepalive probes.
+	DefaultTCPKeepAlive = 300 * time.Second
+	// MaxStringSize is the maximum size of a string value extended by SETRANGE command.
+	MaxStringSize = 512 * 1024 * 1024
+	// MaxRandomMemberCount is the maximum absolute count of SRANDMEMBER command with a negative count which allows duplicated members.
+	MaxRandomMemberCount = 1024 * 1024
+	// DefaultProtoMaxBulkLen is the default maximum length of a request bulk string.
+	DefaultProtoMaxBulkLen = 512 << 20
+	// DefaultProtoMaxMultiBulkLen is the default maximum number of elements of a request array.
+	DefaultProtoMaxMultiBulkLen = 1024 * 1024
+	// DefaultClientQueryBufferLimit is the default maximum size of a client request.
+	DefaultClientQueryBufferLimit = 1 << 30
+	// DefaultShutdownTimeout is the default duration to wait for connections to be closed by SHUTDOWN command.
+	DefaultShutdownTimeout = 10 * time.Second
+	// DefaultShutdownAbortWindow is the default duration in which SHUTDOWN ABORT can cancel the shutdown, 0 means disabled.
+	DefaultShutdownAbortWindow = time.Duration(0)
 	// DefaultScanCount is the default scan count.
 	DefaultScanCount = 10
 	// DefaultScanPattern is the default scan pattern.
@@ -34,3 +85,20 @@ const (
 const (
 	OK = "OK"
 )
+
+const (
+	minAcceptRetryDelay = 5 * time.Millisecond
+	maxAcceptRetryDelay = time.Second
+)
+
+const (
+	// clientAddressTag is the span tag name of client addresses.
+	clientAddressTag = "client.address"
+)
+
+const (
+	// DefaultUser is the user name of connections authenticated without user names.
+	DefaultUser = "default"
+	// AdminGroup is the credential group of users allowed to run administrative commands.
+	AdminGroup = "admin"
+)
@@ -16,6 +16,7 @@ package redis
 
 import (
 	"errors"
+	"math/rand/v2"
 	"strconv"
 	"strings"
 	"time"
@@ -32,19 +33,18 @@ func (server *server) registerCoreExecutors() {
 			err          error
 		)
 
-		passwd, err = args.NextString()
-		if err != nil {
-			return nil, newMissingArgumentError(cmd, "password", err)
-		}
-
-		if msg, _ := args.Next(); msg != nil {
-			token, err := msg.String()
-			if err != nil {
-				return nil, err
+		// AUTH [username] password
+		if args.Len() < 2 {
+			passwd, err = args.String(0)
+		} else {
+			user, err = args.String(0)
+			if err == nil {
+				passwd, err = args.String(1)
 			}
+		}
 
-			user = passwd
-			passwd = token
+		if err != nil {
+			return nil, newMissingArgumentError(cmd, "password", err)
 		}
 
 		return server.Auth(conn, user, passwd)
@@ -54,8 +54,8 @@ func (server *server) registerCoreExecutors() {
 		arg := ""
 
 		var err error
-		if msg, _ := args.Next(); msg != nil {
-			arg, err = msg.String()
+		if 0 < args.Len() {
+			arg, err = args.String(0)
 			if err != nil {
 				return nil, err
 			}
@@ -65,7 +65,7 @@ func (server *server) registerCoreExecutors() {
 	})
 
 	server.RegisterExexutor("ECHO", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
-		msg, err := args.NextString()
+		msg, err := args.String(0)
 		if err != nil {
 			return nil, newMissingArgumentError(cmd, "msg", err)
 		}
@@ -74,7 +74,7 @@ func (server *server) registerCoreExecutors() {
 	})
 
 	server.RegisterExexutor("SELECT", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
-		id, err := args.NextInteger()
+		id, err := args.Int(0)
 		if err != nil {
 			return nil, newMissingArgumentError(cmd, "id", err)
 		}
@@ -97,23 +97,25 @@ func (server *server) registerCoreExecutors() {
 		opt := ""
 
 		var err error
-		if msg, _ := args.Next(); msg != nil {
-			opt, err = msg.String()
+		if 0 < args.Len() {
+			opt, err = args.String(0)
 			if err != nil {
 				return nil, err
 			}
 		}
 
+		cfgArgs := args.Slice(1, args.Len())
+
 		switch strings.ToUpper(opt) {
 		case "SET":
-			params, err := nextStringMapArguments(cmd, args)
+			params, err := nextStringMapArguments(cmd, cfgArgs)
 			if err != nil {
 				return nil, err
 			}
 
 			return server.systemCommandHandler.ConfigSet(conn, params)
 		case "GET":
-			params, err := nextStringArrayArguments(cmd, "params", args)
+			params, err := nextStringArrayArguments(cmd, "params", cfgArgs)
 			if err != nil {
 				return nil, err
 			}
@@ -124,6 +126,20 @@ func (server *server) registerCoreExecutors() {
 		return nil, errors.New(opt)
 	})
 
+	server.RegisterExexutor("SHUTDOWN", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		if !server.isAdminConn(conn) {
+			username, _ := conn.UserName()
+			return nil, newNoPermissionError(username, strings.ToLower(cmd))
+		}
+
+		opt, err := nextShutdownArguments(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		return server.shutdown(conn, opt)
+	})
+
 	// Generic commands.
 
 	server.RegisterExexutor("DEL", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
@@ -232,13 +248,202 @@ func (server *server) registerCoreExecutors() {
 		return server.userCommandHandler.Rename(conn, key, newkey, RenameOption{NX: true})
 	})
 
+	server.RegisterExexutor("PEXPIRE", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextKeyArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		ttl, err := nextIntegerArgument(cmd, "milliseconds", args)
+		if err != nil {
+			return nil, err
+		}
+
+		ttlTime := time.Now().Add(time.Duration(ttl) * time.Millisecond)
+
+		opt, err := nextExpireArgument(cmd, ttlTime, args)
+		if err != nil {
+			return nil, err
+		}
+
+		return server.userCommandHandler.Expire(conn, key, opt)
+	})
+
+	server.RegisterExexutor("PEXPIREAT", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextKeyArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		ttl, err := nextIntegerArgument(cmd, "unix-time-milliseconds", args)
+		if err != nil {
+			return nil, err
+		}
+
+		ttlTime := time.UnixMilli(int64(ttl))
+
+		opt, err := nextExpireArgument(cmd, ttlTime, args)
+		if err != nil {
+			return nil, err
+		}
+
+		return server.userCommandHandler.Expire(conn, key, opt)
+	})
+
 	server.RegisterExexutor("TTL", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
 		key, err := nextKeyArgument(cmd, args)
 		if err != nil {
 			return nil, err
 		}
 
-		return server.userCommandHandler.TTL(conn, key)
+		if _, ok := server.userCommandHandler.(ExpireTimeHandler); !ok {
+			return server.userCommandHandler.TTL(conn, key)
+		}
+
+		exp, err := server.keyExpiration(conn, key)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewIntegerMessage(exp.TTL(time.Now(), time.Second)), nil
+	})
+
+	server.RegisterExexutor("PTTL", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextKeyArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		exp, err := server.keyExpiration(conn, key)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewIntegerMessage(exp.TTL(time.Now(), time.Millisecond)), nil
+	})
+
+	server.RegisterExexutor("EXPIRETIME", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextKeyArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		exp, err := server.keyExpiration(conn, key)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewIntegerMessage(exp.UnixTime(time.Second)), nil
+	})
+
+	server.RegisterExexutor("PEXPIRETIME", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextKeyArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		exp, err := server.keyExpiration(conn, key)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewIntegerMessage(exp.UnixTime(time.Millisecond)), nil
+	})
+
+	server.RegisterExexutor("UNLINK", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		keys, err := nextKeysArguments(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		return server.userCommandHandler.Del(conn, keys)
+	})
+
+	server.RegisterExexutor("TOUCH", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		keys, err := nextKeysArguments(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		return server.userCommandHandler.Exists(conn, keys)
+	})
+
+	server.RegisterExexutor("COPY", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		handler, ok := server.userCommandHandler.(CopyHandler)
+		if !ok {
+			return nil, NewErrNotSupported(cmd)
+		}
+
+		src, dst, opt, err := nextCopyArguments(cmd, conn.Database(), args)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.Copy(conn, src, dst, opt)
+	})
+
+	server.RegisterExexutor("MOVE", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		handler, ok := server.userCommandHandler.(MoveHandler)
+		if !ok {
+			return nil, NewErrNotSupported(cmd)
+		}
+
+		key, err := nextKeyArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		db, err := nextDatabaseArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		if db == conn.Database() {
+			return nil, ErrSameObject
+		}
+
+		return handler.Move(conn, key, db)
+	})
+
+	server.RegisterExexutor("PERSIST", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		handler, ok := server.userCommandHandler.(PersistHandler)
+		if !ok {
+			return nil, NewErrNotSupported(cmd)
+		}
+
+		key, err := nextKeyArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.Persist(conn, key)
+	})
+
+	server.RegisterExexutor("RANDOMKEY", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		if handler, ok := server.userCommandHandler.(RandomKeyHandler); ok {
+			return handler.RandomKey(conn)
+		}
+
+		res, err := server.userCommandHandler.Keys(conn, "*")
+		if err != nil {
+			return nil, err
+		}
+
+		array, err := res.Array()
+		if err != nil {
+			return nil, err
+		}
+
+		keys, err := array.NextMessages()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(keys) == 0 {
+			return NewNilMessage(), nil
+		}
+
+		return keys[rand.IntN(len(keys))], nil //nolint:gosec // RANDOMKEY does not need a secure random number.
 	})
 
 	server.RegisterExexutor("SCAN", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
@@ -252,7 +457,12 @@ func (server *server) registerCoreExecutors() {
 			return nil, err
 		}
 
-		return server.userCommandHandler.Scan(conn, cursor, opt)
+		res, err := server.userCommandHandler.Scan(conn, cursor, opt)
+		if err != nil || len(opt.KeyType) == 0 {
+			return res, err
+		}
+
+		return server.filterScanKeyType(conn, res, opt.KeyType)
 	})
 
 	// String commands.
@@ -281,7 +491,7 @@ func (server *server) registerCoreExecutors() {
 	})
 
 	server.RegisterExexutor("SETEX", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
-		key, seconds, val, err := nextSetExArguments(cmd, args)
+		key, seconds, val, err := nextSetExArguments(cmd, "seconds", args)
 		if err != nil {
 			return nil, err
 		}
@@ -292,6 +502,18 @@ func (server *server) registerCoreExecutors() {
 		return server.userCommandHandler.Set(conn, key, val, opt)
 	})
 
+	server.RegisterExexutor("PSETEX", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, milliseconds, val, err := nextSetExArguments(cmd, "milliseconds", args)
+		if err != nil {
+			return nil, err
+		}
+
+		opt := newDefaultSetOption()
+		opt.PX = time.Duration(milliseconds) * time.Millisecond
+
+		return server.userCommandHandler.Set(conn, key, val, opt)
+	})
+
 	server.RegisterExexutor("GETSET", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
 		opt := newDefaultSetOption()
 		opt.GET = true
@@ -523,6 +745,20 @@ func (server *server) registerCoreExecutors() {
 		return server.userCommandHandler.LIndex(conn, key, idx)
 	})
 
+	server.RegisterExexutor("LINSERT", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		handler, ok := server.userCommandHandler.(ListMutationHandler)
+		if !ok {
+			return nil, NewErrNotSupported(cmd)
+		}
+
+		key, pivot, elem, opt, err := nextLInsertArguments(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.LInsert(conn, key, pivot, elem, opt)
+	})
+
 	server.RegisterExexutor("LLEN", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
 		key, err := nextKeyArgument(cmd, args)
 		if err != nil {
@@ -582,6 +818,78 @@ func (server *server) registerCoreExecutors() {
 		return server.userCommandHandler.LRange(conn, key, start, end)
 	})
 
+	server.RegisterExexutor("LREM", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		handler, ok := server.userCommandHandler.(ListMutationHandler)
+		if !ok {
+			return nil, NewErrNotSupported(cmd)
+		}
+
+		key, err := nextKeyArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		count, err := nextIntegerArgument(cmd, "count", args)
+		if err != nil {
+			return nil, err
+		}
+
+		elem, err := nextStringArgument(cmd, "element", args)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.LRem(conn, key, count, elem)
+	})
+
+	server.RegisterExexutor("LSET", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		handler, ok := server.userCommandHandler.(ListMutationHandler)
+		if !ok {
+			return nil, NewErrNotSupported(cmd)
+		}
+
+		key, err := nextKeyArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		idx, err := nextIntegerArgument(cmd, "index", args)
+		if err != nil {
+			return nil, err
+		}
+
+		elem, err := nextStringArgument(cmd, "element", args)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.LSet(conn, key, idx, elem)
+	})
+
+	server.RegisterExexutor("LTRIM", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		handler, ok := server.userCommandHandler.(ListMutationHandler)
+		if !ok {
+			return nil, NewErrNotSupported(cmd)
+		}
+
+		key, err := nextKeyArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		start, err := nextIntegerArgument(cmd, "start", args)
+		if err != nil {
+			return nil, err
+		}
+
+		stop, err := nextIntegerArgument(cmd, "stop", args)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler.LTrim(conn, key, start, stop)
+	})
+
 	server.RegisterExexutor("RPOP", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
 		key, cnt, err := nextPopArguments(cmd, args)
 		if err != nil {
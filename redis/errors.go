@@ -20,11 +20,33 @@ import (
 )
 
 var (
-	ErrNotSupported = errors.New("not supported")
-	ErrQuit         = errors.New("QUIT")
-	ErrSystem       = errors.New("internal system error")
-	ErrNotAuthrized = errors.New("not authrized")
-	ErrInvalid      = errors.New("invalid")
+	ErrNotSupported         = errors.New("not supported")
+	ErrQuit                 = errors.New("QUIT")
+	ErrSystem               = errors.New("internal system error")
+	ErrNotAuthrized         = errors.New("not authrized")
+	ErrInvalid              = errors.New("invalid")
+	ErrProtectedMode        = errors.New("DENIED Redis is running in protected mode because protected mode is enabled and no password is set for the default user. In this mode connections are only accepted from the loopback interface. If you want to connect from external computers to Redis you may adopt one of the following solutions: 1) Just disable protected mode sending the command 'CONFIG SET protected-mode no' from the loopback interface by connecting to Redis from the same host the server is running, however MAKE SURE Redis is not publicly accessible from internet if you do so. Use CONFIG REWRITE to make this change permanent. 2) Alternatively you can just disable the protected mode by editing the Redis configuration file, and setting the protected mode option to 'no', and then restarting the server. 3) If you started the server manually just for testing, restart it with the '--protected-mode no' option. 4) Set up an authentication password for the default user. NOTE: You only need to do one of the above things in order for the server to start accepting connections from the outside.")
+	ErrTLSRequired          = errors.New("ERR TLS is required for non-loopback clients")
+	ErrConnClosed           = errors.New("connection closed")
+	ErrCommandTimeout       = errors.New("command timeout")
+	ErrOffsetOutOfRange     = errors.New("offset is out of range")
+	ErrStringExceedsMaxSize = errors.New("ERR string exceeds maximum allowed size")
+	ErrValueOutOfRange      = errors.New("ERR value is out of range")
+	ErrDBIndexOutOfRange    = errors.New("ERR DB index is out of range")
+	ErrSameObject           = errors.New("ERR source and destination objects are the same")
+	ErrWrongType            = errors.New("WRONGTYPE Operation against a key holding the wrong kind of value")
+	ErrSortScore            = errors.New("ERR One or more scores can't be converted into double")
+	ErrNoSuchKey            = errors.New("ERR no such key")
+	ErrIndexOutOfRange      = errors.New("ERR index out of range")
+	ErrNumKeysExceedArgs    = errors.New("ERR Number of keys can't be greater than number of args")
+	ErrMaxClients           = errors.New("ERR max number of clients reached")
+	ErrMaxClientsIP         = errors.New("ERR max number of clients per IP reached")
+	ErrOutputBufferLimit    = errors.New("client output buffer limit exceeded")
+	ErrShutdownAborted      = errors.New("shutdown aborted")
+	ErrShutdownSave         = errors.New("ERR Errors trying to SHUTDOWN. Check logs.")
+	ErrNoShutdownInProgress = errors.New("ERR No shutdown in progress.")
+	ErrInvalidArgument      = errors.New("ERR Invalid argument")
+	ErrIncrOverflow         = errors.New("ERR increment or decrement would overflow")
 )
 
 const (
@@ -34,6 +56,10 @@ const (
 	errorInvalidCommandArgument = "%s: %w argument (%s - %s)"
 	errorUseOnlyOnce            = "%s may be used only once"
 	errorShouldBeGreaterThanInt = "%s should be greater than %d"
+	errorUseExclusively         = "%s should be used exclusively"
+	errorNoPermission           = "NOPERM User %s has no permissions to run the '%s' command"
+	errorNotUnixSocket          = "%s is not a Unix domain socket"
+	errorUnixSocketInUse        = "%s is already in use"
 )
 
 // NewErrNotSupported returns a new ErrNotSupported.
@@ -57,3 +83,7 @@ func newUnkownArgumentError(cmd string, arg string) error {
 func newInvalidArgumentError(cmd string, arg string, err error) error {
 	return fmt.Errorf(errorInvalidCommandArgument, cmd, ErrInvalid, arg, err.Error())
 }
+
+func newNoPermissionError(user string, cmd string) error {
+	return fmt.Errorf(errorNoPermission, user, cmd)
+}
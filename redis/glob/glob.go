@@ -14,41 +14,212 @@
 
 package glob
 
-import (
-	"regexp"
-	"strings"
-)
+// Glob represents a glob-style pattern which matches strings as Redis stringmatchlen.
+// The pattern supports `*`, `?`, character classes such as `[a-z]` and `[^abc]`, and backslash escapes,
+// and the pattern and the matched strings are binary-safe.
+type Glob struct {
+	pattern string
+	nocase  bool
+}
+
+// Option represents an option of glob-style patterns.
+type Option func(*Glob)
 
-// Glob is a regular expression for glob-style patterns.
-type Glob = regexp.Regexp
+// WithNoCase returns an option to match strings case-insensitively.
+func WithNoCase() Option {
+	return func(g *Glob) {
+		g.nocase = true
+	}
+}
 
 // MustCompile compiles the specified glob-style patterns.
-func MustCompile(pattern string) *Glob {
-	return regexp.MustCompile(regexpFromGlob(pattern))
+func MustCompile(pattern string, opts ...Option) *Glob {
+	g, _ := Compile(pattern, opts...)
+	return g
 }
 
 // Compile compiles the specified glob-style patterns.
-func Compile(pattern string) (*Glob, error) {
-	return regexp.Compile(regexpFromGlob(pattern))
+// Any patterns are valid as Redis, so the returned error is always nil.
+func Compile(pattern string, opts ...Option) (*Glob, error) {
+	g := &Glob{
+		pattern: pattern,
+		nocase:  false,
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g, nil
+}
+
+// String returns the source pattern.
+func (g *Glob) String() string {
+	return g.pattern
 }
 
-func regexpFromGlob(pattern string) string {
-	// Syntax · google/re2 Wiki
-	// https://github.com/google/re2/wiki/Syntax
-	// glob (programming) - Wikipedia
-	// https://en.wikipedia.org/wiki/Glob_(programming)
-	repstrs := []struct {
-		old string
-		new string
-	}{
-		{old: "*", new: ".*"},
-		{old: "?", new: "."},
+// isMatchAll returns true if the pattern is `*` which matches any strings including empty strings as KEYS and SCAN of Redis.
+func (g *Glob) isMatchAll() bool {
+	return g.pattern == "*"
+}
+
+// MatchString returns true if the specified string matches the pattern.
+func (g *Glob) MatchString(str string) bool {
+	if g.isMatchAll() {
+		return true
 	}
 
-	re2Pattern := pattern
-	for _, repstr := range repstrs {
-		re2Pattern = strings.ReplaceAll(re2Pattern, repstr.old, repstr.new)
+	skipLongerMatches := false
+	return stringMatch(g.pattern, str, g.nocase, &skipLongerMatches, 0)
+}
+
+// Match returns true if the specified bytes match the pattern.
+func (g *Glob) Match(b []byte) bool {
+	if g.isMatchAll() {
+		return true
+	}
+
+	skipLongerMatches := false
+	return stringMatch(g.pattern, b, g.nocase, &skipLongerMatches, 0)
+}
+
+// maxNesting is the maximum nesting level of `*` to protect against abusive patterns.
+const maxNesting = 1000
+
+func toLower(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + ('a' - 'A')
+	}
+
+	return c
+}
+
+func equalByte(a byte, b byte, nocase bool) bool {
+	if nocase {
+		return toLower(a) == toLower(b)
+	}
+
+	return a == b
+}
+
+// stringMatch is a port of stringmatchlen of Redis.
+// nolint: gocognit, gocyclo, cyclop
+func stringMatch[T string | []byte](pattern string, str T, nocase bool, skipLongerMatches *bool, nesting int) bool {
+	if maxNesting < nesting {
+		return false
+	}
+
+	p, s := 0, 0
+
+	for p < len(pattern) && s < len(str) {
+		switch pattern[p] {
+		case '*':
+			for p+1 < len(pattern) && pattern[p+1] == '*' {
+				p++
+			}
+
+			if p+1 == len(pattern) {
+				return true
+			}
+
+			for s < len(str) {
+				if stringMatch(pattern[p+1:], str[s:], nocase, skipLongerMatches, nesting+1) {
+					return true
+				}
+
+				if *skipLongerMatches {
+					return false
+				}
+
+				s++
+			}
+
+			// No longer matches of the earlier `*` can match the rest of the pattern either.
+			*skipLongerMatches = true
+
+			return false
+		case '?':
+			s++
+		case '[':
+			p++
+
+			not := p < len(pattern) && pattern[p] == '^'
+			if not {
+				p++
+			}
+
+			match := false
+
+			for {
+				if len(pattern) <= p {
+					// Treats the unterminated class as terminated at the end of the pattern.
+					p--
+					break
+				}
+
+				if pattern[p] == '\\' && 2 <= len(pattern)-p {
+					p++
+
+					if pattern[p] == str[s] {
+						match = true
+					}
+				} else if pattern[p] == ']' {
+					break
+				} else if 3 <= len(pattern)-p && pattern[p+1] == '-' {
+					start, end, c := pattern[p], pattern[p+2], str[s]
+					if end < start {
+						start, end = end, start
+					}
+
+					if nocase {
+						start, end, c = toLower(start), toLower(end), toLower(c)
+					}
+
+					p += 2
+
+					if start <= c && c <= end {
+						match = true
+					}
+				} else if equalByte(pattern[p], str[s], nocase) {
+					match = true
+				}
+
+				p++
+			}
+
+			if not {
+				match = !match
+			}
+
+			if !match {
+				return false
+			}
+
+			s++
+		case '\\':
+			if 2 <= len(pattern)-p {
+				p++
+			}
+
+			fallthrough
+		default:
+			if !equalByte(pattern[p], str[s], nocase) {
+				return false
+			}
+
+			s++
+		}
+
+		p++
+
+		if s == len(str) {
+			for p < len(pattern) && pattern[p] == '*' {
+				p++
+			}
+
+			break
+		}
 	}
 
-	return "^" + re2Pattern + "$"
+	return p == len(pattern) && s == len(str)
 }
@@ -33,6 +33,37 @@ func TestGlobMatches(t *testing.T) {
 		{"*name*_keys", "lastname_keys", true},
 		{"*name*_keys", "firstname_keys", true},
 		{"*name*_keys", "age_keys", false},
+		// Regular expression metacharacters are literal.
+		{"user.[0-9]*", "user.1000", true},
+		{"user.[0-9]*", "userX1000", false},
+		{"a+b(c)", "a+b(c)", true},
+		{"a+b(c)", "aab(c)", false},
+		{"^$|", "^$|", true},
+		// Character classes.
+		{"h[ae]llo", "hello", true},
+		{"h[ae]llo", "hillo", false},
+		{"h[^e]llo", "hallo", true},
+		{"h[^e]llo", "hello", false},
+		{"h[a-b]llo", "hbllo", true},
+		{"h[b-a]llo", "hallo", true},
+		{"h[a-b]llo", "hcllo", false},
+		{"[\\\\]", "\\", true},
+		{"[\\]]", "]", true},
+		{"[\\]", "\\", false},
+		{"[abc", "b", true},
+		{"[abc", "d", false},
+		// Escapes.
+		{"h\\*llo", "h*llo", true},
+		{"h\\*llo", "hello", false},
+		{"h\\?llo", "h?llo", true},
+		{"a\\", "a\\", true},
+		{"\\[a]", "[a]", true},
+		// Stars.
+		{"*", "", true},
+		{"**a**", "bab", true},
+		{"a*", "", false},
+		{"a*b*c", "aXbYc", true},
+		{"a*b*c", "aXbY", false},
 	}
 
 	for _, tt := range tests {
@@ -46,3 +77,52 @@ func TestGlobMatches(t *testing.T) {
 		}
 	}
 }
+
+func TestGlobNoCase(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"HELLO", "hello", true},
+		{"h[A-C]llo", "hbllo", true},
+		{"h[^A-C]llo", "hBllo", false},
+		{"*KEY?", "my_keys", true},
+	}
+
+	for _, tt := range tests {
+		glob := MustCompile(tt.pattern, WithNoCase())
+		if got := glob.MatchString(tt.value); got != tt.want {
+			t.Errorf("Glob(%s).MatchString(%s) = %v, want %v", tt.pattern, tt.value, got, tt.want)
+		}
+
+		if got := MustCompile(tt.pattern).MatchString(tt.value); got && tt.pattern != tt.value {
+			t.Errorf("Glob(%s).MatchString(%s) = %v, want %v", tt.pattern, tt.value, got, false)
+		}
+	}
+}
+
+func TestGlobBinary(t *testing.T) {
+	glob := MustCompile("\x00[\x80-\xff]*\xff")
+
+	if !glob.Match([]byte{0x00, 0x90, 0x01, 0xff}) {
+		t.Errorf("Glob(%q).Match() = false, want true", glob.String())
+	}
+
+	if glob.Match([]byte{0x00, 0x10, 0xff}) {
+		t.Errorf("Glob(%q).Match() = true, want false", glob.String())
+	}
+}
+
+func TestGlobAbusivePattern(t *testing.T) {
+	glob := MustCompile("a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*b")
+
+	value := make([]byte, 1024)
+	for n := range value {
+		value[n] = 'a'
+	}
+
+	if glob.Match(value) {
+		t.Errorf("Glob(%s).Match() = true, want false", glob.String())
+	}
+}
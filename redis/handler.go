@@ -29,6 +29,7 @@ type ServerManagementCommandHandler interface {
 }
 
 // GenericCommandHandler represents a hander interface for genelic commands.
+// UNLINK and TOUCH commands are implemented by Del and Exists, and RANDOMKEY command is implemented by Keys unless RandomKeyHandler is implemented.
 type GenericCommandHandler interface {
 	Del(conn *Conn, keys []string) (*Message, error)
 	Exists(conn *Conn, keys []string) (*Message, error)
@@ -37,11 +38,17 @@ type GenericCommandHandler interface {
 	Rename(conn *Conn, key string, newkey string, opt RenameOption) (*Message, error)
 	Type(conn *Conn, key string) (*Message, error)
 	TTL(conn *Conn, key string) (*Message, error)
+	// Scan represents a handler interface for SCAN command, and returns an array of the next cursor and the matched keys.
+	// A full iteration starts with the cursor 0 and ends when the returned cursor is 0. The full iteration must return
+	// every key which is present from the start to the end at least once even while keys are added or removed,
+	// and may return keys more than once, or keys which are added or removed during the iteration.
 	Scan(conn *Conn, cursor int, opt ScanOption) (*Message, error)
 }
 
 // StringCommandHandler represents a core command hander interface for string commands.
 // APPEND, DECR, DECRBY, GETRANGE, GETSET, INCR, INCRBY, MGET, MSET, MSETNX, SETRANGE, STRLEN commands are implemented by the StringCommandHandler.
+// The read-modify-write commands are serialized against each other by the per-key lock unless the optional handlers such as IncrByHandler are implemented,
+// but the lock does not serialize them against Set and the other handlers which are called without the lock.
 type StringCommandHandler interface {
 	// Set represents a handler interface for SET, SETNX, SETEX, PSETEX, MSET and MSETNX commands.
 	Set(conn *Conn, key string, val string, opt SetOption) (*Message, error)
@@ -50,7 +57,7 @@ type StringCommandHandler interface {
 }
 
 // HashCommandHandler represents a core command hander interface for hash commands.
-// HMSET and HMGET commands are implemented by the HashCommandHandler.
+// HMSET, HMGET and HINCRBY commands are implemented by the HashCommandHandler.
 type HashCommandHandler interface {
 	// HDel represents a handler interface for HDEL command.
 	HDel(conn *Conn, key string, fields []string) (*Message, error)
@@ -96,11 +103,200 @@ type ZSetCommandHandler interface {
 	ZIncBy(conn *Conn, key string, inc float64, member string) (*Message, error)
 }
 
+// ListMutationHandler represents an optional hander interface which UserCommandHandler can implement to support LSET, LINSERT, LREM and LTRIM commands.
+type ListMutationHandler interface {
+	// LSet represents a handler interface which sets the element at the index, and returns an OK message or ErrNoSuchKey and ErrIndexOutOfRange errors.
+	LSet(conn *Conn, key string, index int, element string) (*Message, error)
+	// LInsert represents a handler interface which inserts the element before or after the pivot,
+	// and returns the new length, -1 if the pivot is not found, or 0 if the key does not exist as an integer message.
+	LInsert(conn *Conn, key string, pivot string, element string, opt LInsertOption) (*Message, error)
+	// LRem represents a handler interface which removes the count occurrences of the element from the head, from the tail if the count is negative,
+	// or all occurrences if the count is 0, and returns the number of removed elements as an integer message.
+	LRem(conn *Conn, key string, count int, element string) (*Message, error)
+	// LTrim represents a handler interface which trims the list to the range, and returns an OK message.
+	LTrim(conn *Conn, key string, start int, stop int) (*Message, error)
+}
+
+// LMoveHandler represents an optional hander interface which UserCommandHandler can implement to move list elements atomically.
+// LMOVE and RPOPLPUSH commands call the handler instead of the pop and push handlers of ListCommandHandler if the user handler implements the interface.
+type LMoveHandler interface {
+	// LMove represents a handler interface which pops an element from the source list and pushes it to the destination list,
+	// and returns the element as a bulk message or a nil message if the source list is empty.
+	LMove(conn *Conn, src string, dst string, from ListDirection, to ListDirection) (*Message, error)
+}
+
+// SPopHandler represents an optional hander interface which UserCommandHandler can implement to pop random set members atomically.
+// SPOP command calls the handler instead of SMembers and SRem of SetCommandHandler if the user handler implements the interface.
+type SPopHandler interface {
+	// SPop represents a handler interface which removes up to the count random members and returns them as an array message.
+	SPop(conn *Conn, key string, count int) (*Message, error)
+}
+
+// SRandMemberHandler represents an optional hander interface which UserCommandHandler can implement to return random set members without listing all members.
+// SRANDMEMBER command picks members from SMembers of SetCommandHandler if the user handler does not implement the interface.
+type SRandMemberHandler interface {
+	// SRandMember represents a handler interface which returns up to the count distinct random members as an array message,
+	// or the absolute count members which may be duplicated if the count is negative.
+	SRandMember(conn *Conn, key string, count int) (*Message, error)
+}
+
+// SMoveHandler represents an optional hander interface which UserCommandHandler can implement to move set members atomically.
+// SMOVE command calls the handler instead of SMembers, SRem and SAdd of SetCommandHandler if the user handler implements the interface.
+type SMoveHandler interface {
+	// SMove represents a handler interface which moves the member from the source set to the destination set and returns 1 if moved, otherwise 0 as an integer message.
+	SMove(conn *Conn, src string, dst string, member string) (*Message, error)
+}
+
+// SetAlgebraHandler represents an optional hander interface which UserCommandHandler can implement to compute set algebra natively.
+// SINTER, SINTERCARD, SUNION, SDIFF and the STORE variants are computed from SMembers of SetCommandHandler if the user handler does not implement the interface,
+// and the STORE variants replace the destination by Del and SAdd with the computed members.
+type SetAlgebraHandler interface {
+	// SInter represents a handler interface which returns the members of the intersection of the sets as an array message.
+	SInter(conn *Conn, keys []string) (*Message, error)
+	// SUnion represents a handler interface which returns the members of the union of the sets as an array message.
+	SUnion(conn *Conn, keys []string) (*Message, error)
+	// SDiff represents a handler interface which returns the members of the difference between the first set and the other sets as an array message.
+	SDiff(conn *Conn, keys []string) (*Message, error)
+}
+
+// ExpireTimeHandler represents an optional hander interface which UserCommandHandler can implement to return expiration times in millisecond precision.
+// TTL, PTTL, EXPIRETIME and PEXPIRETIME commands derive their replies from the handler if the user handler implements the interface,
+// otherwise they derive from TTL of GenericCommandHandler in second precision.
+type ExpireTimeHandler interface {
+	// ExpireTime represents a handler interface which returns the absolute expiration time of the key.
+	ExpireTime(conn *Conn, key string) (KeyExpiration, error)
+}
+
+// CopyHandler represents an optional hander interface which UserCommandHandler can implement to support COPY command.
+type CopyHandler interface {
+	// Copy represents a handler interface which copies the source key to the destination key and returns 1 if copied, otherwise 0 as an integer message.
+	Copy(conn *Conn, src string, dst string, opt CopyOption) (*Message, error)
+}
+
+// MoveHandler represents an optional hander interface which UserCommandHandler can implement to support MOVE command.
+type MoveHandler interface {
+	// Move represents a handler interface which moves the key to the database and returns 1 if moved, otherwise 0 as an integer message.
+	Move(conn *Conn, key string, db DatabaseID) (*Message, error)
+}
+
+// PersistHandler represents an optional hander interface which UserCommandHandler can implement to support PERSIST command.
+type PersistHandler interface {
+	// Persist represents a handler interface which removes the expiration of the key and returns 1 if removed, otherwise 0 as an integer message.
+	Persist(conn *Conn, key string) (*Message, error)
+}
+
+// RandomKeyHandler represents an optional hander interface which UserCommandHandler can implement to return random keys without listing all keys.
+// RANDOMKEY command picks a key from Keys of GenericCommandHandler if the user handler does not implement the interface.
+type RandomKeyHandler interface {
+	// RandomKey represents a handler interface which returns a random key as a bulk message, or a nil message if the database is empty.
+	RandomKey(conn *Conn) (*Message, error)
+}
+
+// IncrByHandler represents an optional hander interface which UserCommandHandler can implement to increment values atomically.
+// INCR, INCRBY, DECR and DECRBY commands call the handler instead of Get and Set of StringCommandHandler if the user handler implements the interface.
+type IncrByHandler interface {
+	// IncrBy represents a handler interface which increments the integer value of the key and returns the new value as an integer message.
+	IncrBy(conn *Conn, key string, inc int) (*Message, error)
+}
+
+// AppendHandler represents an optional hander interface which UserCommandHandler can implement to append values atomically.
+// APPEND command calls the handler instead of Get and Set of StringCommandHandler if the user handler implements the interface.
+type AppendHandler interface {
+	// Append represents a handler interface which appends the value to the key and returns the new length as an integer message.
+	Append(conn *Conn, key string, val string) (*Message, error)
+}
+
+// HIncrByHandler represents an optional hander interface which UserCommandHandler can implement to increment hash fields atomically.
+// HINCRBY command calls the handler instead of HGet and HSet of HashCommandHandler if the user handler implements the interface.
+type HIncrByHandler interface {
+	// HIncrBy represents a handler interface which increments the integer value of the hash field and returns the new value as an integer message.
+	HIncrBy(conn *Conn, key string, field string, inc int) (*Message, error)
+}
+
+// HScanHandler represents an optional hander interface which UserCommandHandler can implement to iterate hash fields with cursors.
+// HSCAN command returns all fields by HGetAll of HashCommandHandler in a single iteration if the user handler does not implement the interface.
+type HScanHandler interface {
+	// HScan represents a handler interface for HSCAN command with the same cursor contract as Scan.
+	HScan(conn *Conn, key string, cursor int, opt ScanOption) (*Message, error)
+}
+
+// SScanHandler represents an optional hander interface which UserCommandHandler can implement to iterate set members with cursors.
+// SSCAN command returns all members by SMembers of SetCommandHandler in a single iteration if the user handler does not implement the interface.
+type SScanHandler interface {
+	// SScan represents a handler interface for SSCAN command with the same cursor contract as Scan.
+	SScan(conn *Conn, key string, cursor int, opt ScanOption) (*Message, error)
+}
+
+// ZScanHandler represents an optional hander interface which UserCommandHandler can implement to iterate sorted set members with cursors.
+// ZSCAN command returns all members by ZRange of ZSetCommandHandler in a single iteration if the user handler does not implement the interface.
+type ZScanHandler interface {
+	// ZScan represents a handler interface for ZSCAN command with the same cursor contract as Scan.
+	ZScan(conn *Conn, key string, cursor int, opt ScanOption) (*Message, error)
+}
+
+// StringBytesCommandHandler represents an optional hander interface which UserCommandHandler can implement to handle string commands with binary-safe keys and values.
+// The core string commands call the bytes handlers instead of StringCommandHandler without string conversions if the user handler implements the interface.
+type StringBytesCommandHandler interface {
+	// SetBytes represents a handler interface for SET, SETNX, SETEX, PSETEX, GETSET, MSET and MSETNX commands.
+	SetBytes(conn *Conn, key []byte, val []byte, opt SetOption) (*Message, error)
+	// GetBytes represents a handler interface for GET, MGET and MSETNX commands.
+	GetBytes(conn *Conn, key []byte) (*Message, error)
+}
+
+// HashBytesCommandHandler represents an optional hander interface which UserCommandHandler can implement to handle hash commands with binary-safe keys, fields and values.
+// The core hash commands call the bytes handlers instead of HashCommandHandler without string conversions if the user handler implements the interface.
+type HashBytesCommandHandler interface {
+	// HDelBytes represents a handler interface for HDEL command.
+	HDelBytes(conn *Conn, key []byte, fields [][]byte) (*Message, error)
+	// HSetBytes represents a handler interface for HSET, HSETNX and HMSET commands.
+	HSetBytes(conn *Conn, key []byte, field []byte, val []byte, opt HSetOption) (*Message, error)
+	// HGetBytes represents a handler interface for HGET and HMGET commands.
+	HGetBytes(conn *Conn, key []byte, field []byte) (*Message, error)
+	// HGetAllBytes represents a handler interface for HGETALL command.
+	HGetAllBytes(conn *Conn, key []byte) (*Message, error)
+}
+
+// ListBytesCommandHandler represents an optional hander interface which UserCommandHandler can implement to handle list commands with binary-safe keys and elements.
+// The core list commands call the bytes handlers instead of ListCommandHandler without string conversions if the user handler implements the interface.
+type ListBytesCommandHandler interface {
+	// LPushBytes represents a handler interface for LPUSH and LPUSHX commands.
+	LPushBytes(conn *Conn, key []byte, elements [][]byte, opt PushOption) (*Message, error)
+	// RPushBytes represents a handler interface for RPUSH and RPUSHX commands.
+	RPushBytes(conn *Conn, key []byte, elements [][]byte, opt PushOption) (*Message, error)
+	// LPopBytes represents a handler interface for LPOP command.
+	LPopBytes(conn *Conn, key []byte, count int) (*Message, error)
+	// RPopBytes represents a handler interface for RPOP command.
+	RPopBytes(conn *Conn, key []byte, count int) (*Message, error)
+	// LRangeBytes represents a handler interface for LRANGE command.
+	LRangeBytes(conn *Conn, key []byte, start int, stop int) (*Message, error)
+	// LIndexBytes represents a handler interface for LINDEX command.
+	LIndexBytes(conn *Conn, key []byte, index int) (*Message, error)
+	// LLenBytes represents a handler interface for LLEN command.
+	LLenBytes(conn *Conn, key []byte) (*Message, error)
+}
+
+// SetBytesCommandHandler represents an optional hander interface which UserCommandHandler can implement to handle set commands with binary-safe keys and members.
+// The core set commands call the bytes handlers instead of SetCommandHandler without string conversions if the user handler implements the interface.
+type SetBytesCommandHandler interface {
+	// SAddBytes represents a handler interface for SADD command.
+	SAddBytes(conn *Conn, key []byte, members [][]byte) (*Message, error)
+	// SMembersBytes represents a handler interface for SMEMBERS command.
+	SMembersBytes(conn *Conn, key []byte) (*Message, error)
+	// SRemBytes represents a handler interface for SREM command.
+	SRemBytes(conn *Conn, key []byte, members [][]byte) (*Message, error)
+}
+
 // AuthCommandHandler represents a hander interface for authentication commands.
 type AuthCommandHandler interface {
 	Auth(conn *Conn, username string, password string) (*Message, error)
 }
 
+// PersistenceHandler represents an optional hander interface which UserCommandHandler can implement to persist the datasets.
+type PersistenceHandler interface {
+	// Save represents a handler interface called by SHUTDOWN command before the server is stopped unless NOSAVE is specified.
+	Save(conn *Conn, opt ShutdownOption) error
+}
+
 // UserCommandHandler represents a command hander interface for user commands.
 type UserCommandHandler interface {
 	GenericCommandHandler
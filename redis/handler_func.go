@@ -17,7 +17,6 @@ package redis
 import (
 	"errors"
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -47,38 +46,60 @@ func nextStringArgument(cmd string, name string, args Arguments) (string, error)
 }
 
 func nextFloatArgument(cmd string, name string, args Arguments) (float64, error) {
-	str, err := args.NextString()
+	val, err := args.NextFloat()
 	if err != nil {
 		return 0, newMissingArgumentError(cmd, name, err)
 	}
 
-	score, err := strconv.ParseFloat(str, 64)
+	return val, nil
+}
+
+func nextStringArrayArguments(cmd string, name string, args Arguments) ([]string, error) {
+	strs, err := args.NextStrings()
 	if err != nil {
-		return 0, newMissingArgumentError(cmd, name, err)
+		return nil, newMissingArgumentError(cmd, name, err)
 	}
 
-	return score, nil
+	return strs, nil
 }
 
-func nextStringArrayArguments(cmd string, name string, args Arguments) ([]string, error) {
-	var (
-		str string
-		err error
-	)
+func nextBytesArgument(cmd string, name string, args Arguments) ([]byte, error) {
+	b, err := args.NextBytes()
+	if err != nil {
+		return nil, newMissingArgumentError(cmd, name, err)
+	}
 
-	strs := []string{}
+	return b, nil
+}
 
-	str, err = args.NextString()
-	for err == nil {
-		strs = append(strs, str)
-		str, err = args.NextString()
+func nextBytesArrayArguments(cmd string, name string, args Arguments) ([][]byte, error) {
+	msgs, err := args.NextMessages()
+	if err != nil {
+		return nil, newMissingArgumentError(cmd, name, err)
 	}
 
-	if !errors.Is(err, proto.ErrEOM) {
-		return nil, newMissingArgumentError(cmd, name, err)
+	vals := make([][]byte, len(msgs))
+	for n, msg := range msgs {
+		vals[n], err = msg.Bytes()
+		if err != nil {
+			return nil, newMissingArgumentError(cmd, name, err)
+		}
 	}
 
-	return strs, nil
+	return vals, nil
+}
+
+func nextBytesPairArguments(cmd string, name string, args Arguments) ([][]byte, error) {
+	vals, err := nextBytesArrayArguments(cmd, name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vals)%2 != 0 {
+		return nil, newMissingArgumentError(cmd, name, proto.ErrEOM)
+	}
+
+	return vals, nil
 }
 
 func nextStringMapArguments(cmd string, args Arguments) (map[string]string, error) {
@@ -117,6 +138,35 @@ func nextKeysArguments(cmd string, args Arguments) ([]string, error) {
 	return nextStringArrayArguments(cmd, "keys", args)
 }
 
+// nextNumKeysArguments returns the keys following the numkeys argument without trusting numkeys to allocate them.
+func nextNumKeysArguments(cmd string, args Arguments) ([]string, error) {
+	numKeys, err := nextIntegerArgument(cmd, "numkeys", args)
+	if err != nil {
+		return nil, err
+	}
+
+	if numKeys < 1 {
+		return nil, newInvalidArgumentError(cmd, "numkeys", fmt.Errorf(errorShouldBeGreaterThanInt, "numkeys", 0))
+	}
+
+	if args.Len()-args.Pos() < numKeys {
+		return nil, ErrNumKeysExceedArgs
+	}
+
+	keys := []string{}
+
+	for range numKeys {
+		key, err := nextKeyArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
 // String argument functions
 
 func nextSetArguments(cmd string, args Arguments) (string, string, error) {
@@ -133,19 +183,19 @@ func nextSetArguments(cmd string, args Arguments) (string, string, error) {
 	return key, val, err
 }
 
-func nextSetExArguments(cmd string, args Arguments) (string, int, string, error) {
+func nextSetExArguments(cmd string, name string, args Arguments) (string, int, string, error) {
 	key, err := args.NextString()
 	if err != nil {
 		return "", 0, "", newMissingArgumentError(cmd, "key", err)
 	}
 
-	seconds, err := args.NextInteger()
+	expire, err := args.NextInteger()
 	if err != nil {
-		return "", 0, "", newMissingArgumentError(cmd, "seconds", err)
+		return "", 0, "", newMissingArgumentError(cmd, name, err)
 	}
 
-	if seconds < 1 {
-		return "", 0, "", newInvalidArgumentError(cmd, "seconds", fmt.Errorf(errorShouldBeGreaterThanInt, "argument", 0))
+	if expire < 1 {
+		return "", 0, "", newInvalidArgumentError(cmd, name, fmt.Errorf(errorShouldBeGreaterThanInt, "argument", 0))
 	}
 
 	val, err := args.NextString()
@@ -153,7 +203,7 @@ func nextSetExArguments(cmd string, args Arguments) (string, int, string, error)
 		return "", 0, "", newMissingArgumentError(cmd, "value", err)
 	}
 
-	return key, seconds, val, err
+	return key, expire, val, err
 }
 
 func nextMGetArguments(cmd string, args Arguments) ([]string, error) {
@@ -276,6 +326,151 @@ func nextPopArguments(cmd string, args Arguments) (string, int, error) {
 	return key, cnt, nil
 }
 
+func nextListDirectionArgument(cmd string, name string, args Arguments) (ListDirection, error) {
+	dir, err := nextStringArgument(cmd, name, args)
+	if err != nil {
+		return ListLeft, err
+	}
+
+	switch strings.ToUpper(dir) {
+	case "LEFT":
+		return ListLeft, nil
+	case "RIGHT":
+		return ListRight, nil
+	}
+
+	return ListLeft, newUnkownArgumentError(cmd, dir)
+}
+
+func nextLInsertArguments(cmd string, args Arguments) (string, string, string, LInsertOption, error) {
+	opt := LInsertOption{BEFORE: false}
+
+	key, err := nextKeyArgument(cmd, args)
+	if err != nil {
+		return "", "", "", opt, err
+	}
+
+	where, err := nextStringArgument(cmd, "where", args)
+	if err != nil {
+		return "", "", "", opt, err
+	}
+
+	switch strings.ToUpper(where) {
+	case "BEFORE":
+		opt.BEFORE = true
+	case "AFTER":
+		opt.BEFORE = false
+	default:
+		return "", "", "", opt, newUnkownArgumentError(cmd, where)
+	}
+
+	pivot, err := nextStringArgument(cmd, "pivot", args)
+	if err != nil {
+		return "", "", "", opt, err
+	}
+
+	elem, err := nextStringArgument(cmd, "element", args)
+	if err != nil {
+		return "", "", "", opt, err
+	}
+
+	return key, pivot, elem, opt, nil
+}
+
+func nextLPosArguments(cmd string, args Arguments) (string, string, LPosOption, error) {
+	opt := LPosOption{
+		Rank:   1,
+		Count:  -1,
+		MaxLen: 0,
+	}
+
+	key, err := nextKeyArgument(cmd, args)
+	if err != nil {
+		return "", "", opt, err
+	}
+
+	elem, err := nextStringArgument(cmd, "element", args)
+	if err != nil {
+		return "", "", opt, err
+	}
+
+	param, err := args.NextString()
+	for err == nil {
+		switch strings.ToUpper(param) {
+		case "RANK":
+			opt.Rank, err = nextIntegerArgument(cmd, "rank", args)
+			if err == nil && opt.Rank == 0 {
+				return "", "", opt, newInvalidArgumentError(cmd, "RANK", fmt.Errorf(errorShouldBeGreaterThanInt, "RANK", 0))
+			}
+		case "COUNT":
+			opt.Count, err = nextIntegerArgument(cmd, "num-matches", args)
+			if err == nil && opt.Count < 0 {
+				return "", "", opt, newInvalidArgumentError(cmd, "COUNT", fmt.Errorf(errorShouldBeGreaterThanInt, "COUNT", -1))
+			}
+		case "MAXLEN":
+			opt.MaxLen, err = nextIntegerArgument(cmd, "len", args)
+			if err == nil && opt.MaxLen < 0 {
+				return "", "", opt, newInvalidArgumentError(cmd, "MAXLEN", fmt.Errorf(errorShouldBeGreaterThanInt, "MAXLEN", -1))
+			}
+		default:
+			return "", "", opt, newUnkownArgumentError(cmd, param)
+		}
+
+		if err != nil {
+			return "", "", opt, err
+		}
+
+		param, err = args.NextString()
+	}
+
+	if !errors.Is(err, proto.ErrEOM) {
+		return "", "", opt, newMissingArgumentError(cmd, "", err)
+	}
+
+	return key, elem, opt, nil
+}
+
+func nextLMPopArguments(cmd string, args Arguments) ([]string, ListDirection, int, error) {
+	keys, err := nextNumKeysArguments(cmd, args)
+	if err != nil {
+		return nil, ListLeft, 0, err
+	}
+
+	dir, err := nextListDirectionArgument(cmd, "where", args)
+	if err != nil {
+		return nil, ListLeft, 0, err
+	}
+
+	count := 1
+
+	param, err := args.NextString()
+	if err == nil {
+		if strings.ToUpper(param) != "COUNT" {
+			return nil, ListLeft, 0, newUnkownArgumentError(cmd, param)
+		}
+
+		count, err = nextIntegerArgument(cmd, "count", args)
+		if err != nil {
+			return nil, ListLeft, 0, err
+		}
+
+		if count < 1 {
+			return nil, ListLeft, 0, newInvalidArgumentError(cmd, "COUNT", fmt.Errorf(errorShouldBeGreaterThanInt, "COUNT", 0))
+		}
+
+		param, err = args.NextString()
+		if err == nil {
+			return nil, ListLeft, 0, newUnkownArgumentError(cmd, param)
+		}
+	}
+
+	if !errors.Is(err, proto.ErrEOM) {
+		return nil, ListLeft, 0, newMissingArgumentError(cmd, "", err)
+	}
+
+	return keys, dir, count, nil
+}
+
 // ZSet fuctions
 
 func nextScoreArgument(cmd string, name string, args Arguments) (float64, error) {
@@ -364,10 +559,8 @@ func nextExpireArgument(cmd string, ttl time.Time, args Arguments) (ExpireOption
 		LT:   false,
 	}
 
-	var err error
-
 	arg, err := args.NextString()
-	if err == nil {
+	for err == nil {
 		switch strings.ToUpper(arg) {
 		case "NX":
 			opt.NX = true
@@ -380,26 +573,301 @@ func nextExpireArgument(cmd string, ttl time.Time, args Arguments) (ExpireOption
 		default:
 			return opt, newUnkownArgumentError(cmd, arg)
 		}
+
+		arg, err = args.NextString()
 	}
 
 	if !errors.Is(err, proto.ErrEOM) {
 		return opt, err
 	}
 
+	if opt.NX && (opt.XX || opt.GT || opt.LT) {
+		return opt, newInvalidArgumentError(cmd, "NX", fmt.Errorf(errorUseExclusively, "NX"))
+	}
+
+	if opt.GT && opt.LT {
+		return opt, newInvalidArgumentError(cmd, "GT", fmt.Errorf(errorUseOnlyOnce, "GT|LT"))
+	}
+
+	return opt, nil
+}
+
+// Set argument fuctions
+
+// nextOptionalCountArgument returns the optional count argument, and false if the count is not specified.
+func nextOptionalCountArgument(cmd string, args Arguments) (int, bool, error) {
+	if args.Len() <= args.Pos() {
+		return 0, false, nil
+	}
+
+	count, err := nextIntegerArgument(cmd, "count", args)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if param, err := args.NextString(); err == nil {
+		return 0, false, newUnkownArgumentError(cmd, param)
+	}
+
+	return count, true, nil
+}
+
+func nextSetAlgebraKeysArguments(cmd string, args Arguments) ([]string, error) {
+	keys, err := nextKeysArguments(cmd, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, newMissingArgumentError(cmd, "key", proto.ErrEOM)
+	}
+
+	return keys, nil
+}
+
+func nextSInterCardArguments(cmd string, args Arguments) ([]string, int, error) {
+	keys, err := nextNumKeysArguments(cmd, args)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	limit := 0
+
+	param, err := args.NextString()
+	if err == nil {
+		if strings.ToUpper(param) != "LIMIT" {
+			return nil, 0, newUnkownArgumentError(cmd, param)
+		}
+
+		limit, err = nextIntegerArgument(cmd, "limit", args)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if limit < 0 {
+			return nil, 0, newInvalidArgumentError(cmd, "limit", fmt.Errorf(errorShouldBeGreaterThanInt, "limit", -1))
+		}
+
+		param, err = args.NextString()
+		if err == nil {
+			return nil, 0, newUnkownArgumentError(cmd, param)
+		}
+	}
+
+	if !errors.Is(err, proto.ErrEOM) {
+		return nil, 0, newMissingArgumentError(cmd, "", err)
+	}
+
+	return keys, limit, nil
+}
+
+// Sort argument fuctions
+
+func nextSortArguments(cmd string, readOnly bool, args Arguments) (string, SortOption, error) {
+	opt := SortOption{
+		BY:     "",
+		GET:    []string{},
+		Offset: 0,
+		Count:  -1,
+		DESC:   false,
+		ALPHA:  false,
+		STORE:  "",
+	}
+
+	key, err := nextKeyArgument(cmd, args)
+	if err != nil {
+		return "", opt, err
+	}
+
+	param, err := args.NextString()
+	for err == nil {
+		switch strings.ToUpper(param) {
+		case "BY":
+			opt.BY, err = nextStringArgument(cmd, "pattern", args)
+		case "GET":
+			var pattern string
+
+			pattern, err = nextStringArgument(cmd, "pattern", args)
+			opt.GET = append(opt.GET, pattern)
+		case "LIMIT":
+			opt.Offset, err = nextIntegerArgument(cmd, "offset", args)
+			if err == nil {
+				opt.Count, err = nextIntegerArgument(cmd, "count", args)
+			}
+		case "ASC":
+			opt.DESC = false
+		case "DESC":
+			opt.DESC = true
+		case "ALPHA":
+			opt.ALPHA = true
+		case "STORE":
+			if readOnly {
+				return "", opt, newUnkownArgumentError(cmd, param)
+			}
+
+			opt.STORE, err = nextStringArgument(cmd, "destination", args)
+		default:
+			return "", opt, newUnkownArgumentError(cmd, param)
+		}
+
+		if err != nil {
+			return "", opt, err
+		}
+
+		param, err = args.NextString()
+	}
+
+	if !errors.Is(err, proto.ErrEOM) {
+		return "", opt, newMissingArgumentError(cmd, "", err)
+	}
+
+	return key, opt, nil
+}
+
+// Copy argument fuctions
+
+func nextDatabaseArgument(cmd string, args Arguments) (DatabaseID, error) {
+	id, err := nextIntegerArgument(cmd, "db", args)
+	if err != nil {
+		return 0, err
+	}
+
+	if id < 0 {
+		return 0, ErrDBIndexOutOfRange
+	}
+
+	return id, nil
+}
+
+func nextCopyArguments(cmd string, db DatabaseID, args Arguments) (string, string, CopyOption, error) {
+	opt := CopyOption{
+		DB:      db,
+		REPLACE: false,
+	}
+
+	src, err := nextKeyArgument(cmd, args)
+	if err != nil {
+		return "", "", opt, err
+	}
+
+	dst, err := nextStringArgument(cmd, "destination", args)
+	if err != nil {
+		return "", "", opt, err
+	}
+
+	param, err := args.NextString()
+	for err == nil {
+		switch strings.ToUpper(param) {
+		case "DB":
+			opt.DB, err = nextDatabaseArgument(cmd, args)
+			if err != nil {
+				return "", "", opt, err
+			}
+		case "REPLACE":
+			opt.REPLACE = true
+		default:
+			return "", "", opt, newUnkownArgumentError(cmd, param)
+		}
+
+		param, err = args.NextString()
+	}
+
+	if !errors.Is(err, proto.ErrEOM) {
+		return "", "", opt, newMissingArgumentError(cmd, "", err)
+	}
+
+	if src == dst && opt.DB == db {
+		return "", "", opt, ErrSameObject
+	}
+
+	return src, dst, opt, nil
+}
+
+// Shutdown argument fuctions
+
+func nextShutdownArguments(cmd string, args Arguments) (ShutdownOption, error) {
+	opt := ShutdownOption{
+		NOSAVE: false,
+		SAVE:   false,
+		NOW:    false,
+		FORCE:  false,
+		ABORT:  false,
+	}
+
+	param, err := args.NextString()
+	for err == nil {
+		switch strings.ToUpper(param) {
+		case "NOSAVE":
+			opt.NOSAVE = true
+		case "SAVE":
+			opt.SAVE = true
+		case "NOW":
+			opt.NOW = true
+		case "FORCE":
+			opt.FORCE = true
+		case "ABORT":
+			opt.ABORT = true
+		default:
+			return opt, newUnkownArgumentError(cmd, param)
+		}
+
+		param, err = args.NextString()
+	}
+
+	if !errors.Is(err, proto.ErrEOM) {
+		return opt, newMissingArgumentError(cmd, "", err)
+	}
+
+	if opt.NOSAVE && opt.SAVE {
+		return opt, newInvalidArgumentError(cmd, "SAVE", fmt.Errorf(errorUseOnlyOnce, "NOSAVE|SAVE"))
+	}
+
+	if opt.ABORT && (opt.NOSAVE || opt.SAVE || opt.NOW || opt.FORCE) {
+		return opt, newInvalidArgumentError(cmd, "ABORT", fmt.Errorf(errorUseExclusively, "ABORT"))
+	}
+
 	return opt, nil
 }
 
 // Scan argument fuctions
 
+func nextKeyScanArguments(cmd string, args Arguments) (string, int, ScanOption, error) {
+	var opt ScanOption
+
+	key, err := nextKeyArgument(cmd, args)
+	if err != nil {
+		return "", 0, opt, err
+	}
+
+	cursor, err := nextIntegerArgument(cmd, "cursor", args)
+	if err != nil {
+		return "", 0, opt, err
+	}
+
+	opt, err = nextScanArgument(cmd, args)
+	if err != nil {
+		return "", 0, opt, err
+	}
+
+	return key, cursor, opt, nil
+}
+
 func nextScanArgument(cmd string, args Arguments) (ScanOption, error) {
 	opt := ScanOption{
 		MatchPattern: glob.MustCompile(DefaultScanPattern),
 		Count:        DefaultScanCount,
 		Type:         DefaultScanType,
+		KeyType:      "",
+		NoValues:     false,
 	}
 
 	var err error
 
+	opt.Type, err = newScanTypeFromString(cmd)
+	if err != nil {
+		return opt, err
+	}
+
 	param, err := args.NextString()
 	for err == nil {
 		switch strings.ToUpper(param) {
@@ -411,7 +879,7 @@ func nextScanArgument(cmd string, args Arguments) (ScanOption, error) {
 				return opt, err
 			}
 
-			opt.MatchPattern, err = regexp.Compile(pattern)
+			opt.MatchPattern, err = glob.Compile(pattern)
 			if err != nil {
 				return opt, newInvalidArgumentError(cmd, "pattern", err)
 			}
@@ -420,18 +888,32 @@ func nextScanArgument(cmd string, args Arguments) (ScanOption, error) {
 			if err != nil {
 				return opt, err
 			}
-		case "Type":
-			var scanType string
 
-			scanType, err = nextStringArgument(cmd, "type", args)
+			if opt.Count < 1 {
+				return opt, newInvalidArgumentError(cmd, "count", fmt.Errorf(errorShouldBeGreaterThanInt, "count", 0))
+			}
+		case "TYPE":
+			if opt.Type != KeyScan {
+				return opt, newUnkownArgumentError(cmd, param)
+			}
+
+			opt.KeyType, err = nextStringArgument(cmd, "type", args)
 			if err != nil {
 				return opt, err
 			}
 
-			opt.Type, err = newScanTypeFromString(scanType)
-			if err != nil {
-				return opt, newInvalidArgumentError(cmd, "type", err)
+			opt.KeyType = strings.ToLower(opt.KeyType)
+			if !isScanKeyType(opt.KeyType) {
+				return opt, newInvalidArgumentError(cmd, "type", NewErrNotSupported(opt.KeyType))
+			}
+		case "NOVALUES":
+			if opt.Type != HashScan {
+				return opt, newUnkownArgumentError(cmd, param)
 			}
+
+			opt.NoValues = true
+		default:
+			return opt, newUnkownArgumentError(cmd, param)
 		}
 
 		param, err = args.NextString()
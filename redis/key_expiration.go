@@ -0,0 +1,128 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"time"
+)
+
+const (
+	ttlKeyNotExist = -2
+	ttlNoExpire    = -1
+)
+
+// KeyExpiration represents an absolute expiration time of a key returned by ExpireTimeHandler.
+type KeyExpiration struct {
+	exists bool
+	time   time.Time
+}
+
+// NewKeyExpiration returns a new expiration of an existing key which expires at the specified time.
+func NewKeyExpiration(t time.Time) KeyExpiration {
+	return KeyExpiration{
+		exists: true,
+		time:   t,
+	}
+}
+
+// NewKeyExpirationNoExpire returns a new expiration of an existing key which has no expiration.
+func NewKeyExpirationNoExpire() KeyExpiration {
+	return KeyExpiration{
+		exists: true,
+		time:   time.Time{},
+	}
+}
+
+// NewKeyExpirationNotExist returns a new expiration of a key which does not exist.
+func NewKeyExpirationNotExist() KeyExpiration {
+	return KeyExpiration{
+		exists: false,
+		time:   time.Time{},
+	}
+}
+
+// Exists returns true if the key exists.
+func (exp KeyExpiration) Exists() bool {
+	return exp.exists
+}
+
+// HasExpire returns true if the key exists and has an expiration.
+func (exp KeyExpiration) HasExpire() bool {
+	return exp.exists && !exp.time.IsZero()
+}
+
+// Time returns the expiration time, or the zero time if the key has no expiration.
+func (exp KeyExpiration) Time() time.Time {
+	return exp.time
+}
+
+// TTL returns the remaining time to live in the specified unit, rounded to the nearest unit as Redis does,
+// -1 if the key has no expiration, or -2 if the key does not exist or has already expired.
+func (exp KeyExpiration) TTL(now time.Time, unit time.Duration) int {
+	if !exp.exists {
+		return ttlKeyNotExist
+	}
+
+	if exp.time.IsZero() {
+		return ttlNoExpire
+	}
+
+	ttl := exp.time.Sub(now)
+	if ttl < 0 {
+		return ttlKeyNotExist
+	}
+
+	return int((ttl + unit/2) / unit)
+}
+
+// UnixTime returns the expiration time as a Unix time in the specified unit,
+// -1 if the key has no expiration, or -2 if the key does not exist.
+func (exp KeyExpiration) UnixTime(unit time.Duration) int {
+	if !exp.exists {
+		return ttlKeyNotExist
+	}
+
+	if exp.time.IsZero() {
+		return ttlNoExpire
+	}
+
+	return int(exp.time.UnixNano() / int64(unit))
+}
+
+// keyExpiration returns the expiration of the key by ExpireTimeHandler, or by TTL of GenericCommandHandler in second precision.
+func (server *server) keyExpiration(conn *Conn, key string) (KeyExpiration, error) {
+	if handler, ok := server.userCommandHandler.(ExpireTimeHandler); ok {
+		return handler.ExpireTime(conn, key)
+	}
+
+	res, err := server.userCommandHandler.TTL(conn, key)
+	if err != nil {
+		return NewKeyExpirationNotExist(), err
+	}
+
+	ttl, err := res.Integer()
+	if err != nil {
+		return NewKeyExpirationNotExist(), err
+	}
+
+	switch {
+	case ttl == ttlNoExpire:
+		return NewKeyExpirationNoExpire(), nil
+	case ttl < 0:
+		return NewKeyExpirationNotExist(), nil
+	}
+
+	return NewKeyExpiration(time.Now().Add(time.Duration(ttl) * time.Second)), nil
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"testing"
+	"time"
+)
+
+func TestKeyExpiration(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+
+	tests := []struct {
+		exp         KeyExpiration
+		ttl         int
+		pttl        int
+		expireTime  int
+		pexpireTime int
+	}{
+		{NewKeyExpirationNotExist(), -2, -2, -2, -2},
+		{NewKeyExpirationNoExpire(), -1, -1, -1, -1},
+		{NewKeyExpiration(now.Add(1500 * time.Millisecond)), 2, 1500, 1700000001, 1700000001500},
+		{NewKeyExpiration(now.Add(1499 * time.Millisecond)), 1, 1499, 1700000001, 1700000001499},
+		{NewKeyExpiration(now.Add(-time.Millisecond)), -2, -2, 1699999999, 1699999999999},
+	}
+
+	for _, test := range tests {
+		if got := test.exp.TTL(now, time.Second); got != test.ttl {
+			t.Errorf("TTL: %d != %d", got, test.ttl)
+		}
+
+		if got := test.exp.TTL(now, time.Millisecond); got != test.pttl {
+			t.Errorf("PTTL: %d != %d", got, test.pttl)
+		}
+
+		if got := test.exp.UnixTime(time.Second); got != test.expireTime {
+			t.Errorf("EXPIRETIME: %d != %d", got, test.expireTime)
+		}
+
+		if got := test.exp.UnixTime(time.Millisecond); got != test.pexpireTime {
+			t.Errorf("PEXPIRETIME: %d != %d", got, test.pexpireTime)
+		}
+	}
+}
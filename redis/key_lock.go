@@ -0,0 +1,119 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"slices"
+	"sync"
+)
+
+// keyLockID represents a key in the specified database.
+type keyLockID struct {
+	db  DatabaseID
+	key string
+}
+
+// keyLockEntry represents a lock of a key which is shared by the waiting goroutines.
+type keyLockEntry struct {
+	mutex *sync.Mutex
+	refs  int
+}
+
+// KeyLock represents a per-key lock facility to serialize read-modify-write operations on the same key.
+// The locks are allocated on demand and released when no goroutines hold or wait for them.
+// The server takes the locks only in the fallback compositions of the sugar commands such as INCR, APPEND and SETRANGE,
+// so the sugar commands are atomic against each other, but not against the other commands such as SET and DEL
+// which are dispatched to the user handler without the locks.
+// User handlers which need the atomicity against all commands should implement the optional handlers such as IncrByHandler
+// and serialize them with their own plain command handlers.
+type KeyLock struct {
+	mutex *sync.Mutex
+	locks map[keyLockID]*keyLockEntry
+}
+
+// NewKeyLock returns a new per-key lock facility.
+func NewKeyLock() *KeyLock {
+	return &KeyLock{
+		mutex: &sync.Mutex{},
+		locks: map[keyLockID]*keyLockEntry{},
+	}
+}
+
+// Lock locks the specified key in the specified database.
+func (kl *KeyLock) Lock(db DatabaseID, key string) {
+	id := keyLockID{db: db, key: key}
+
+	kl.mutex.Lock()
+
+	entry, ok := kl.locks[id]
+	if !ok {
+		entry = &keyLockEntry{mutex: &sync.Mutex{}, refs: 0}
+		kl.locks[id] = entry
+	}
+
+	entry.refs++
+
+	kl.mutex.Unlock()
+
+	entry.mutex.Lock()
+}
+
+// Unlock unlocks the specified key in the specified database.
+func (kl *KeyLock) Unlock(db DatabaseID, key string) {
+	id := keyLockID{db: db, key: key}
+
+	kl.mutex.Lock()
+	defer kl.mutex.Unlock()
+
+	entry, ok := kl.locks[id]
+	if !ok {
+		return
+	}
+
+	entry.mutex.Unlock()
+
+	entry.refs--
+	if entry.refs <= 0 {
+		delete(kl.locks, id)
+	}
+}
+
+// LockKeys locks the specified keys in the specified database in the sorted order to avoid deadlocks, and returns the locked keys to unlock.
+func (kl *KeyLock) LockKeys(db DatabaseID, keys ...string) []string {
+	sortedKeys := slices.Clone(keys)
+	slices.Sort(sortedKeys)
+	sortedKeys = slices.Compact(sortedKeys)
+
+	for _, key := range sortedKeys {
+		kl.Lock(db, key)
+	}
+
+	return sortedKeys
+}
+
+// UnlockKeys unlocks the specified keys which are returned by LockKeys.
+func (kl *KeyLock) UnlockKeys(db DatabaseID, keys []string) {
+	for n := len(keys) - 1; 0 <= n; n-- {
+		kl.Unlock(db, keys[n])
+	}
+}
+
+// Len returns the number of the allocated key locks.
+func (kl *KeyLock) Len() int {
+	kl.mutex.Lock()
+	defer kl.mutex.Unlock()
+
+	return len(kl.locks)
+}
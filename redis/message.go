@@ -33,6 +33,16 @@ func NewBulkMessage(msg string) *Message {
 	return proto.NewMessageWithType(proto.BulkMessage).SetBytes([]byte(msg))
 }
 
+// NewBulkMessageBytes creates a bulk string message with the specified bytes without copying them.
+// A nil slice is sent as an empty bulk string, use NewNilMessage for a nil reply.
+func NewBulkMessageBytes(msg []byte) *Message {
+	if msg == nil {
+		msg = []byte{}
+	}
+
+	return proto.NewMessageWithType(proto.BulkMessage).SetBytes(msg)
+}
+
 // NewErrorMessage creates an error message.
 func NewErrorMessage(err error) *Message {
 	return proto.NewMessageWithType(proto.ErrorMessage).SetBytes([]byte(err.Error()))
@@ -77,3 +87,13 @@ func NewStringArrayMessage(strs []string) *Message {
 
 	return proto.NewMessageWithType(proto.ArrayMessage).SetArray(array)
 }
+
+// NewBytesArrayMessage creates an array message with the specified bytes as bulk strings.
+func NewBytesArrayMessage(vals [][]byte) *Message {
+	array := proto.NewArray()
+	for _, val := range vals {
+		array.Append(NewBulkMessageBytes(val))
+	}
+
+	return proto.NewMessageWithType(proto.ArrayMessage).SetArray(array)
+}
@@ -15,6 +15,7 @@
 package redis
 
 import (
+	"strings"
 	"time"
 
 	"github.com/cybergarage/go-redis/redis/glob"
@@ -47,10 +48,50 @@ type RenameOption struct {
 	NX bool
 }
 
+// SortOption represents options of SORT and SORT_RO commands.
+// BY and GET are patterns of external keys such as "weight_*" or "object_*->field", and Count is -1 unless LIMIT is specified.
+type SortOption struct {
+	BY     string
+	GET    []string
+	Offset int
+	Count  int
+	DESC   bool
+	ALPHA  bool
+	STORE  string
+}
+
+// CopyOption represents options of COPY command.
+// DB is the destination database, and is the database of the connection unless the DB option is specified.
+type CopyOption struct {
+	DB      DatabaseID
+	REPLACE bool
+}
+
 type PushOption struct {
 	X bool
 }
 
+// ListDirection represents LEFT or RIGHT of LMOVE and LMPOP commands.
+type ListDirection int
+
+const (
+	ListLeft ListDirection = iota
+	ListRight
+)
+
+// LInsertOption represents options of LINSERT command, BEFORE is false for AFTER.
+type LInsertOption struct {
+	BEFORE bool
+}
+
+// LPosOption represents options of LPOS command.
+// Rank is 1 unless RANK is specified, Count is -1 unless COUNT is specified, and MaxLen is 0 for no limit.
+type LPosOption struct {
+	Rank   int
+	Count  int
+	MaxLen int
+}
+
 type ZAddOption struct {
 	XX   bool
 	NX   bool
@@ -71,6 +112,15 @@ type ZRangeOption struct {
 	Count        int
 }
 
+type ShutdownOption struct {
+	NOSAVE bool
+	SAVE   bool
+	NOW    bool
+	FORCE  bool
+	ABORT  bool
+}
+
+// ScanType represents a command of the SCAN family.
 type ScanType int
 
 const (
@@ -80,18 +130,21 @@ const (
 	SortedSetScan
 )
 
+// ScanOption represents options of the SCAN family commands.
+// Type is the command of the SCAN family, KeyType is the TYPE filter of SCAN such as "hash" or empty for any types,
+// and NoValues is the NOVALUES option of HSCAN.
 type ScanOption struct {
 	MatchPattern *glob.Glob
 	Count        int
 	Type         ScanType
+	KeyType      string
+	NoValues     bool
 }
 
 func newScanTypeFromString(str string) (ScanType, error) {
-	if len(str) == 0 {
+	switch strings.ToUpper(str) {
+	case "", "SCAN":
 		return KeyScan, nil
-	}
-
-	switch str {
 	case "SSCAN":
 		return SetScan, nil
 	case "HSCAN":
@@ -103,6 +156,16 @@ func newScanTypeFromString(str string) (ScanType, error) {
 	return 0, NewErrNotSupported(str)
 }
 
+// isScanKeyType returns true if the specified string is a type name of the TYPE filter.
+func isScanKeyType(str string) bool {
+	switch str {
+	case "string", "list", "set", "zset", "hash", "stream":
+		return true
+	}
+
+	return false
+}
+
 func newDefaultSetOption() SetOption {
 	return SetOption{
 		NX:      false,
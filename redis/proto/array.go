@@ -51,15 +51,19 @@ func newArrayWithParser(parser *Parser) (*Array, error) {
 		return NewArray(), nil
 	}
 
-	// Gets all array messages
-	msgs := make([]*Message, arraySize)
-	for n := range arraySize {
-		msg, err := parser.Next()
+	if 0 < parser.maxMultiBulkLen && parser.maxMultiBulkLen < arraySize {
+		return nil, ErrInvalidMultiBulkLength
+	}
+
+	// Gets all array messages without preallocating untrusted sizes
+	msgs := make([]*Message, 0, min(arraySize, maxPreallocatedArraySize))
+	for range arraySize {
+		msg, err := parser.nextMessage()
 		if err != nil {
 			return nil, err
 		}
 
-		msgs[n] = msg
+		msgs = append(msgs, msg)
 	}
 
 	array := &Array{
@@ -75,6 +79,14 @@ func (array *Array) Append(msg *Message) {
 	array.msgs = append(array.msgs, msg)
 }
 
+// Copy returns a copy of the array which has the same messages and reads from the current position independently.
+func (array *Array) Copy() *Array {
+	return &Array{
+		index: array.index,
+		msgs:  array.msgs,
+	}
+}
+
 // Size returns the array size.
 func (array *Array) Size() int {
 	return len(array.msgs)
@@ -18,3 +18,8 @@ const (
 	cr = '\r'
 	lf = '\n'
 )
+
+const (
+	maxPreallocatedArraySize = 1024
+	maxPreallocatedBulkSize  = 64 * 1024
+)
@@ -30,3 +30,12 @@ var ErrEOM = errors.New("EOM")
 
 // ErrNil is the error returned by Message::String() when the bytes are nil.
 var ErrNil = errors.New("NIL")
+
+// ErrInvalidBulkLength is the error returned by Parser::Next() when a bulk string length exceeds the limit.
+var ErrInvalidBulkLength = errors.New("ERR Protocol error: invalid bulk length")
+
+// ErrInvalidMultiBulkLength is the error returned by Parser::Next() when an array length is invalid.
+var ErrInvalidMultiBulkLength = errors.New("ERR Protocol error: invalid multibulk length")
+
+// ErrQueryBufferLimit is the error returned by Parser::Next() when a message size exceeds the limit.
+var ErrQueryBufferLimit = errors.New("ERR Protocol error: query buffer limit exceeded")
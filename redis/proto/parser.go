@@ -20,18 +20,28 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
+	"slices"
 	"strconv"
 )
 
 // Parser represents a Redis serialization protocol (RESP) parser.
 type Parser struct {
-	reader *bufio.Reader
+	reader          *bufio.Reader
+	maxBulkLen      int
+	maxMultiBulkLen int
+	maxQueryLen     int
+	queryLen        int
 }
 
 // NewParserWithReader returns a new parser for the specified reader.
 func NewParserWithReader(msgReader io.Reader) *Parser {
 	Parser := &Parser{
-		reader: bufio.NewReader(msgReader),
+		reader:          bufio.NewReader(msgReader),
+		maxBulkLen:      0,
+		maxMultiBulkLen: 0,
+		maxQueryLen:     0,
+		queryLen:        0,
 	}
 
 	return Parser
@@ -42,15 +52,50 @@ func NewParserWithBytes(msgBytes []byte) *Parser {
 	return NewParserWithReader(bytes.NewBuffer(msgBytes))
 }
 
+// SetMaxBulkLength sets the maximum length of a bulk string, 0 means unlimited.
+func (parser *Parser) SetMaxBulkLength(n int) {
+	parser.maxBulkLen = n
+}
+
+// SetMaxMultiBulkLength sets the maximum number of elements of an array, 0 means unlimited.
+func (parser *Parser) SetMaxMultiBulkLength(n int) {
+	parser.maxMultiBulkLen = n
+}
+
+// SetMaxQueryLength sets the maximum length of a message including the nested messages, 0 means unlimited.
+func (parser *Parser) SetMaxQueryLength(n int) {
+	parser.maxQueryLen = n
+}
+
+// addQueryLength adds the specified length to the current message length, and checks the limit.
+func (parser *Parser) addQueryLength(n int) error {
+	parser.queryLen += n
+	if 0 < parser.maxQueryLen && parser.maxQueryLen < parser.queryLen {
+		return ErrQueryBufferLimit
+	}
+
+	return nil
+}
+
+// readByte reads a next byte.
+func (parser *Parser) readByte() (byte, error) {
+	b, err := parser.reader.ReadByte()
+	if err != nil {
+		return b, err
+	}
+
+	return b, parser.addQueryLength(1)
+}
+
 // nextLineBytes gets a next line bytes.
 func (parser *Parser) nextLineBytes() ([]byte, error) {
 	var readBytes bytes.Buffer
 
 	// Gets a message bytes.
-	readByte, err := parser.reader.ReadByte()
+	readByte, err := parser.readByte()
 	for err == nil && readByte != cr {
 		readBytes.WriteByte(readByte)
-		readByte, err = parser.reader.ReadByte()
+		readByte, err = parser.readByte()
 	}
 
 	if err != nil {
@@ -61,7 +106,7 @@ func (parser *Parser) nextLineBytes() ([]byte, error) {
 	}
 
 	// Skips a next line field.
-	_, err = parser.reader.ReadByte()
+	_, err = parser.readByte()
 	if err != nil {
 		return nil, err
 	}
@@ -77,16 +122,35 @@ func (parser *Parser) nextLineBytes() ([]byte, error) {
 
 // get next bulk message bytes of length num.
 func (parser *Parser) nextLengthBytes(num int) ([]byte, error) {
+	if 0 < parser.maxBulkLen && parser.maxBulkLen < num {
+		return nil, ErrInvalidBulkLength
+	}
+
+	// Rejects lengths which overflow with the trailing CRLF
+	if math.MaxInt-2 < num {
+		return nil, ErrInvalidBulkLength
+	}
+
 	n := num + 2 // + crlf
-	buf := make([]byte, n)
+	if err := parser.addQueryLength(n); err != nil {
+		return nil, err
+	}
+
+	// Grows the buffer as the data arrives without preallocating untrusted sizes
+	buf := make([]byte, 0, min(n, maxPreallocatedBulkSize))
+
+	for len(buf) < n {
+		if len(buf) == cap(buf) {
+			buf = slices.Grow(buf, min(n-len(buf), cap(buf)))
+		}
+
+		read, err := parser.reader.Read(buf[len(buf):min(n, cap(buf))])
+		buf = buf[:len(buf)+read]
 
-	totalRead := 0
-	for totalRead < n {
-		read, err := parser.reader.Read(buf[totalRead:])
 		if err != nil {
 			if err == io.EOF {
-				if totalRead+read < n {
-					return nil, fmt.Errorf(errorInvalidBulkStringLength, totalRead+read, num)
+				if len(buf) < n {
+					return nil, fmt.Errorf(errorInvalidBulkStringLength, len(buf), num)
 				}
 
 				break
@@ -94,8 +158,6 @@ func (parser *Parser) nextLengthBytes(num int) ([]byte, error) {
 
 			return nil, err
 		}
-
-		totalRead += read
 	}
 
 	if buf[num] != cr || buf[num+1] != lf {
@@ -153,7 +215,13 @@ func (parser *Parser) nextArrayMessage() (*Message, error) {
 
 // Next returns a next message.
 func (parser *Parser) Next() (*Message, error) {
-	typeByte, err := parser.reader.ReadByte()
+	parser.queryLen = 0
+	return parser.nextMessage()
+}
+
+// nextMessage returns a next message including the nested messages.
+func (parser *Parser) nextMessage() (*Message, error) {
+	typeByte, err := parser.readByte()
 	if err != nil {
 		if errors.Is(err, io.EOF) {
 			return nil, nil
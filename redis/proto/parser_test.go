@@ -16,7 +16,9 @@ package proto
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"runtime"
 	"testing"
 )
 
@@ -383,3 +385,107 @@ func TestParserArrayMessages(t *testing.T) {
 		}
 	}
 }
+
+func TestParserLimits(t *testing.T) {
+	tests := []struct {
+		message         string
+		maxBulkLen      int
+		maxMultiBulkLen int
+		maxQueryLen     int
+		expected        error
+	}{
+		{
+			message:         "$5\r\nhello\r\n",
+			maxBulkLen:      5,
+			maxMultiBulkLen: 0,
+			maxQueryLen:     0,
+			expected:        nil,
+		},
+		{
+			message:         "$5\r\nhello\r\n",
+			maxBulkLen:      4,
+			maxMultiBulkLen: 0,
+			maxQueryLen:     0,
+			expected:        ErrInvalidBulkLength,
+		},
+		{
+			message:         "$1073741824\r\n",
+			maxBulkLen:      0,
+			maxMultiBulkLen: 0,
+			maxQueryLen:     1024,
+			expected:        ErrQueryBufferLimit,
+		},
+		{
+			message:         "*2\r\n$5\r\nhello\r\n$5\r\nworld\r\n",
+			maxBulkLen:      0,
+			maxMultiBulkLen: 0,
+			maxQueryLen:     16,
+			expected:        ErrQueryBufferLimit,
+		},
+		{
+			message:         "$9223372036854775807\r\n",
+			maxBulkLen:      0,
+			maxMultiBulkLen: 0,
+			maxQueryLen:     0,
+			expected:        ErrInvalidBulkLength,
+		},
+		{
+			message:         "*1073741824\r\n",
+			maxBulkLen:      0,
+			maxMultiBulkLen: 1024,
+			maxQueryLen:     0,
+			expected:        ErrInvalidMultiBulkLength,
+		},
+		{
+			message:         "*4\r\n$1\r\na\r\n$1\r\nb\r\n$1\r\nc\r\n$1\r\nd\r\n",
+			maxBulkLen:      0,
+			maxMultiBulkLen: 3,
+			maxQueryLen:     0,
+			expected:        ErrInvalidMultiBulkLength,
+		},
+		{
+			message:         "*3\r\n$1\r\na\r\n$1\r\nb\r\n$1\r\nc\r\n",
+			maxBulkLen:      0,
+			maxMultiBulkLen: 3,
+			maxQueryLen:     0,
+			expected:        nil,
+		},
+		{
+			message:         "+hello world\r\n",
+			maxBulkLen:      0,
+			maxMultiBulkLen: 0,
+			maxQueryLen:     8,
+			expected:        ErrQueryBufferLimit,
+		},
+	}
+
+	for _, tt := range tests {
+		parser := NewParserWithBytes([]byte(tt.message))
+		parser.SetMaxBulkLength(tt.maxBulkLen)
+		parser.SetMaxMultiBulkLength(tt.maxMultiBulkLen)
+		parser.SetMaxQueryLength(tt.maxQueryLen)
+
+		_, err := parser.Next()
+		if !errors.Is(err, tt.expected) {
+			t.Errorf("%q: %v != %v", tt.message, err, tt.expected)
+		}
+	}
+}
+
+func TestParserTruncatedBulkMessage(t *testing.T) {
+	var before, after runtime.MemStats
+
+	runtime.ReadMemStats(&before)
+
+	// The declared length is not allocated up front, and the truncated message is rejected.
+	parser := NewParserWithBytes([]byte("$536870912\r\nhello"))
+	if _, err := parser.Next(); err == nil {
+		t.Errorf("truncated bulk message is accepted")
+	}
+
+	runtime.ReadMemStats(&after)
+
+	if allocated := after.TotalAlloc - before.TotalAlloc; (1 << 20) < allocated {
+		t.Errorf("%d bytes are allocated", allocated)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bufio"
+	"net"
+)
+
+// Conn represents a connection accepted via PROXY protocol.
+type Conn struct {
+	net.Conn
+	reader *bufio.Reader
+	header *Header
+}
+
+// NewConn reads a PROXY protocol header from the specified connection, and returns a connection which reports the original client address.
+func NewConn(conn net.Conn) (*Conn, error) {
+	reader := bufio.NewReader(conn)
+
+	header, err := ReadHeader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Conn{
+		Conn:   conn,
+		reader: reader,
+		header: header,
+	}, nil
+}
+
+// Read reads data following the PROXY protocol header.
+func (conn *Conn) Read(b []byte) (int, error) {
+	return conn.reader.Read(b)
+}
+
+// Header returns the PROXY protocol header.
+func (conn *Conn) Header() *Header {
+	return conn.header
+}
+
+// RemoteAddr returns the original client address, or the proxy address if the header has no client address.
+func (conn *Conn) RemoteAddr() net.Addr {
+	if conn.header.Command == Proxy && conn.header.SourceAddr != nil {
+		return conn.header.SourceAddr
+	}
+
+	return conn.Conn.RemoteAddr()
+}
+
+// LocalAddr returns the original destination address, or the local address if the header has no destination address.
+func (conn *Conn) LocalAddr() net.Addr {
+	if conn.header.Command == Proxy && conn.header.DestinationAddr != nil {
+		return conn.header.DestinationAddr
+	}
+
+	return conn.Conn.LocalAddr()
+}
+
+// ProxyAddr returns the address of the proxy.
+func (conn *Conn) ProxyAddr() net.Addr {
+	return conn.Conn.RemoteAddr()
+}
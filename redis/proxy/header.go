@@ -0,0 +1,251 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const (
+	v1Prefix       = "PROXY "
+	v1MaxLength    = 107
+	v1TCP4         = "TCP4"
+	v1TCP6         = "TCP6"
+	v1Unknown      = "UNKNOWN"
+	v2HeaderLength = 16
+	v2Version      = 0x20
+	v2AddrIPv4Len  = 12
+	v2AddrIPv6Len  = 36
+	v2AddrUnixLen  = 216
+	v2UnixPathLen  = 108
+)
+
+// v2Signature is the signature of PROXY protocol v2 headers.
+var v2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+// Command represents a command of PROXY protocol headers.
+type Command int
+
+const (
+	// Local represents a connection established by the proxy itself, such as health checks.
+	Local Command = iota
+	// Proxy represents a connection relayed by the proxy on behalf of the client.
+	Proxy
+)
+
+// Header represents a PROXY protocol header.
+type Header struct {
+	// Version is the protocol version, 1 or 2.
+	Version int
+	// Command is the command of the header.
+	Command Command
+	// SourceAddr is the address of the original client, nil if unknown.
+	SourceAddr net.Addr
+	// DestinationAddr is the address of the original destination, nil if unknown.
+	DestinationAddr net.Addr
+}
+
+// ReadHeader reads a PROXY protocol v1 or v2 header from the specified reader.
+func ReadHeader(r *bufio.Reader) (*Header, error) {
+	b, err := r.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+
+	switch b[0] {
+	case v1Prefix[0]:
+		return readV1Header(r)
+	case v2Signature[0]:
+		return readV2Header(r)
+	}
+
+	return nil, fmt.Errorf(errorInvalidHeader, ErrInvalidHeader, strconv.Quote(string(b)))
+}
+
+// readV1Header reads a PROXY protocol v1 header such as "PROXY TCP4 192.0.2.1 192.0.2.2 56324 6379\r\n".
+func readV1Header(r *bufio.Reader) (*Header, error) {
+	line := make([]byte, 0, v1MaxLength)
+
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		line = append(line, c)
+
+		if c == '\n' {
+			break
+		}
+
+		if v1MaxLength <= len(line) {
+			return nil, fmt.Errorf(errorInvalidHeader, ErrInvalidHeader, "too long")
+		}
+	}
+
+	str, ok := strings.CutSuffix(string(line), "\r\n")
+	if !ok || !strings.HasPrefix(str, v1Prefix) {
+		return nil, fmt.Errorf(errorInvalidHeader, ErrInvalidHeader, strconv.Quote(str))
+	}
+
+	fields := strings.Split(str, " ")
+	if len(fields) < 2 {
+		return nil, fmt.Errorf(errorInvalidHeader, ErrInvalidHeader, strconv.Quote(str))
+	}
+
+	header := &Header{
+		Version:         1,
+		Command:         Proxy,
+		SourceAddr:      nil,
+		DestinationAddr: nil,
+	}
+
+	switch fields[1] {
+	case v1Unknown:
+		return header, nil
+	case v1TCP4, v1TCP6:
+	default:
+		return nil, fmt.Errorf(errorInvalidHeader, ErrUnsupportedHeader, fields[1])
+	}
+
+	if len(fields) != 6 {
+		return nil, fmt.Errorf(errorInvalidHeader, ErrInvalidHeader, strconv.Quote(str))
+	}
+
+	src, err := parseV1Addr(fields[1], fields[2], fields[4])
+	if err != nil {
+		return nil, err
+	}
+
+	dst, err := parseV1Addr(fields[1], fields[3], fields[5])
+	if err != nil {
+		return nil, err
+	}
+
+	header.SourceAddr = src
+	header.DestinationAddr = dst
+
+	return header, nil
+}
+
+// parseV1Addr parses the specified address and port of PROXY protocol v1 headers.
+func parseV1Addr(proto string, addr string, port string) (*net.TCPAddr, error) {
+	ip := net.ParseIP(addr)
+	if ip == nil || (ip.To4() != nil) != (proto == v1TCP4) {
+		return nil, fmt.Errorf(errorInvalidHeader, ErrInvalidHeader, addr)
+	}
+
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf(errorInvalidHeader, ErrInvalidHeader, port)
+	}
+
+	return &net.TCPAddr{IP: ip, Port: int(p), Zone: ""}, nil
+}
+
+// readV2Header reads a PROXY protocol v2 binary header.
+func readV2Header(r *bufio.Reader) (*Header, error) {
+	b := make([]byte, v2HeaderLength)
+
+	_, err := io.ReadFull(r, b)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(b[:len(v2Signature)], v2Signature) {
+		return nil, fmt.Errorf(errorInvalidHeader, ErrInvalidHeader, "signature")
+	}
+
+	verCmd := b[12]
+	if verCmd&0xF0 != v2Version {
+		return nil, fmt.Errorf(errorInvalidHeader, ErrUnsupportedHeader, fmt.Sprintf("version 0x%02X", verCmd>>4))
+	}
+
+	payload := make([]byte, binary.BigEndian.Uint16(b[14:16]))
+
+	_, err = io.ReadFull(r, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	header := &Header{
+		Version:         2,
+		Command:         Local,
+		SourceAddr:      nil,
+		DestinationAddr: nil,
+	}
+
+	switch verCmd & 0x0F {
+	case 0x00:
+		// The addresses of LOCAL commands are ignored.
+		return header, nil
+	case 0x01:
+		header.Command = Proxy
+	default:
+		return nil, fmt.Errorf(errorInvalidHeader, ErrUnsupportedHeader, fmt.Sprintf("command 0x%02X", verCmd&0x0F))
+	}
+
+	family := b[13] >> 4
+	transport := b[13] & 0x0F
+
+	switch {
+	case family == 0x1 && len(payload) >= v2AddrIPv4Len:
+		header.SourceAddr, header.DestinationAddr = v2InetAddrs(transport, payload[0:4], payload[4:8], payload[8:12])
+	case family == 0x2 && len(payload) >= v2AddrIPv6Len:
+		header.SourceAddr, header.DestinationAddr = v2InetAddrs(transport, payload[0:16], payload[16:32], payload[32:36])
+	case family == 0x3 && len(payload) >= v2AddrUnixLen:
+		header.SourceAddr = v2UnixAddr(transport, payload[0:v2UnixPathLen])
+		header.DestinationAddr = v2UnixAddr(transport, payload[v2UnixPathLen:v2AddrUnixLen])
+	case family == 0x0:
+		// The addresses of unspecified families are ignored.
+	default:
+		return nil, fmt.Errorf(errorInvalidHeader, ErrInvalidHeader, fmt.Sprintf("family 0x%02X", b[13]))
+	}
+
+	return header, nil
+}
+
+// v2InetAddrs returns the source and destination addresses of PROXY protocol v2 headers.
+func v2InetAddrs(transport byte, src []byte, dst []byte, ports []byte) (net.Addr, net.Addr) {
+	srcIP := net.IP(bytes.Clone(src))
+	dstIP := net.IP(bytes.Clone(dst))
+	srcPort := int(binary.BigEndian.Uint16(ports[0:2]))
+	dstPort := int(binary.BigEndian.Uint16(ports[2:4]))
+
+	if transport == 0x2 {
+		return &net.UDPAddr{IP: srcIP, Port: srcPort, Zone: ""}, &net.UDPAddr{IP: dstIP, Port: dstPort, Zone: ""}
+	}
+
+	return &net.TCPAddr{IP: srcIP, Port: srcPort, Zone: ""}, &net.TCPAddr{IP: dstIP, Port: dstPort, Zone: ""}
+}
+
+// v2UnixAddr returns the Unix domain socket address of PROXY protocol v2 headers.
+func v2UnixAddr(transport byte, path []byte) net.Addr {
+	network := "unix"
+	if transport == 0x2 {
+		network = "unixgram"
+	}
+
+	name, _, _ := bytes.Cut(path, []byte{0})
+
+	return &net.UnixAddr{Name: string(name), Net: network}
+}
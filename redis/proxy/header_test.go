@@ -0,0 +1,132 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestReadHeader(t *testing.T) {
+	v2 := func(verCmd byte, family byte, addrs ...byte) []byte {
+		b := bytes.Clone(v2Signature)
+		b = append(b, verCmd, family, 0, byte(len(addrs)))
+		return append(b, addrs...)
+	}
+
+	tests := []struct {
+		header   []byte
+		command  Command
+		src      string
+		dst      string
+		expected error
+	}{
+		{
+			header:   []byte("PROXY TCP4 192.0.2.1 192.0.2.2 56324 6379\r\n"),
+			command:  Proxy,
+			src:      "192.0.2.1:56324",
+			dst:      "192.0.2.2:6379",
+			expected: nil,
+		},
+		{
+			header:   []byte("PROXY TCP6 2001:db8::1 2001:db8::2 56324 6379\r\n"),
+			command:  Proxy,
+			src:      "[2001:db8::1]:56324",
+			dst:      "[2001:db8::2]:6379",
+			expected: nil,
+		},
+		{
+			header:   []byte("PROXY UNKNOWN\r\n"),
+			command:  Proxy,
+			src:      "",
+			dst:      "",
+			expected: nil,
+		},
+		{
+			header:   []byte("PROXY TCP4 2001:db8::1 192.0.2.2 56324 6379\r\n"),
+			expected: ErrInvalidHeader,
+		},
+		{
+			header:   []byte("PROXY TCP4 192.0.2.1 192.0.2.2 56324\r\n"),
+			expected: ErrInvalidHeader,
+		},
+		{
+			header:   []byte("*1\r\n$4\r\nPING\r\n"),
+			expected: ErrInvalidHeader,
+		},
+		{
+			header:   v2(0x21, 0x11, 192, 0, 2, 1, 192, 0, 2, 2, 0xDC, 0x04, 0x18, 0xEB),
+			command:  Proxy,
+			src:      "192.0.2.1:56324",
+			dst:      "192.0.2.2:6379",
+			expected: nil,
+		},
+		{
+			header:   v2(0x20, 0x00),
+			command:  Local,
+			src:      "",
+			dst:      "",
+			expected: nil,
+		},
+		{
+			header:   v2(0x11, 0x11, 192, 0, 2, 1, 192, 0, 2, 2, 0xDC, 0x04, 0x18, 0xEB),
+			expected: ErrUnsupportedHeader,
+		},
+		{
+			header:   v2(0x21, 0x11, 192, 0, 2, 1),
+			expected: ErrInvalidHeader,
+		},
+	}
+
+	addrString := func(addr interface{ String() string }) string {
+		if addr == nil {
+			return ""
+		}
+		return addr.String()
+	}
+
+	for _, test := range tests {
+		header, err := ReadHeader(bufio.NewReader(bytes.NewReader(test.header)))
+		if test.expected != nil {
+			if !errors.Is(err, test.expected) {
+				t.Errorf("%q: %v != %v", test.header, err, test.expected)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%q: %v", test.header, err)
+			continue
+		}
+
+		if header.Command != test.command {
+			t.Errorf("%q: %v != %v", test.header, header.Command, test.command)
+		}
+
+		if header.SourceAddr != nil || test.src != "" {
+			if src := addrString(header.SourceAddr); src != test.src {
+				t.Errorf("%q: %s != %s", test.header, src, test.src)
+			}
+		}
+
+		if header.DestinationAddr != nil || test.dst != "" {
+			if dst := addrString(header.DestinationAddr); dst != test.dst {
+				t.Errorf("%q: %s != %s", test.header, dst, test.dst)
+			}
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"strconv"
+
+	"github.com/cybergarage/go-redis/redis/proto"
+)
+
+// NewScanMessage creates a reply message of the SCAN family commands with the specified next cursor and elements.
+func NewScanMessage(cursor int, elems *proto.Array) *Message {
+	array := proto.NewArray()
+	array.Append(NewBulkMessage(strconv.Itoa(cursor)))
+	array.Append(NewArrayMessageWithArray(elems))
+
+	return NewArrayMessageWithArray(array)
+}
+
+// parseScanMessage returns the next cursor and elements of the specified reply message of the SCAN family commands.
+func parseScanMessage(msg *Message) (int, *proto.Array, error) {
+	array, err := msg.Array()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	cursor, err := array.NextInteger()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	elems, err := array.NextArray()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return cursor, elems, nil
+}
+
+// scanPairs returns the matched pairs such as fields and values of the specified flat array in a single iteration.
+func scanPairs(array *proto.Array, opt ScanOption, withValues bool) (*Message, error) {
+	elems := proto.NewArray()
+
+	for {
+		key, err := array.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		if key == nil {
+			break
+		}
+
+		val, err := array.NextMessage()
+		if err != nil {
+			return nil, err
+		}
+
+		keyBytes, err := key.Bytes()
+		if err != nil {
+			return nil, err
+		}
+
+		if !opt.MatchPattern.Match(keyBytes) {
+			continue
+		}
+
+		elems.Append(key)
+
+		if withValues {
+			elems.Append(val)
+		}
+	}
+
+	return NewScanMessage(0, elems), nil
+}
+
+// nolint: gocyclo, maintidx
+func (server *server) registerScanExecutors() {
+	server.RegisterExexutor("HSCAN", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, cursor, opt, err := nextKeyScanArguments(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		if handler, ok := server.userCommandHandler.(HScanHandler); ok {
+			return handler.HScan(conn, key, cursor, opt)
+		}
+
+		res, err := server.userCommandHandler.HGetAll(conn, key)
+		if err != nil {
+			return nil, err
+		}
+
+		array, err := res.Array()
+		if err != nil {
+			return nil, err
+		}
+
+		return scanPairs(array, opt, !opt.NoValues)
+	})
+
+	server.RegisterExexutor("SSCAN", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, cursor, opt, err := nextKeyScanArguments(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		if handler, ok := server.userCommandHandler.(SScanHandler); ok {
+			return handler.SScan(conn, key, cursor, opt)
+		}
+
+		res, err := server.userCommandHandler.SMembers(conn, key)
+		if err != nil {
+			return nil, err
+		}
+
+		array, err := res.Array()
+		if err != nil {
+			return nil, err
+		}
+
+		elems := proto.NewArray()
+
+		for {
+			member, err := array.Next()
+			if err != nil {
+				return nil, err
+			}
+
+			if member == nil {
+				break
+			}
+
+			memberBytes, err := member.Bytes()
+			if err != nil {
+				return nil, err
+			}
+
+			if opt.MatchPattern.Match(memberBytes) {
+				elems.Append(member)
+			}
+		}
+
+		return NewScanMessage(0, elems), nil
+	})
+
+	server.RegisterExexutor("ZSCAN", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, cursor, opt, err := nextKeyScanArguments(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		if handler, ok := server.userCommandHandler.(ZScanHandler); ok {
+			return handler.ZScan(conn, key, cursor, opt)
+		}
+
+		rangeOpt := ZRangeOption{
+			BYSCORE:      false,
+			BYLEX:        false,
+			REV:          false,
+			WITHSCORES:   true,
+			MINEXCLUSIVE: false,
+			MAXEXCLUSIVE: false,
+			Offset:       0,
+			Count:        -1,
+		}
+
+		res, err := server.userCommandHandler.ZRange(conn, key, 0, -1, rangeOpt)
+		if err != nil {
+			return nil, err
+		}
+
+		array, err := res.Array()
+		if err != nil {
+			return nil, err
+		}
+
+		return scanPairs(array, opt, true)
+	})
+}
+
+// filterScanKeyType returns the reply message of SCAN command which has only the keys of the specified type.
+func (server *server) filterScanKeyType(conn *Conn, msg *Message, keyType string) (*Message, error) {
+	cursor, keys, err := parseScanMessage(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	elems := proto.NewArray()
+
+	for {
+		key, err := keys.NextString()
+		if err != nil {
+			break
+		}
+
+		res, err := server.userCommandHandler.Type(conn, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if typ, _ := res.String(); typ == keyType {
+			elems.Append(NewBulkMessage(key))
+		}
+	}
+
+	return NewScanMessage(cursor, elems), nil
+}
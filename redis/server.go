@@ -15,6 +15,10 @@
 package redis
 
 import (
+	"context"
+	"crypto/tls"
+	"net"
+
 	"github.com/cybergarage/go-authenticator/auth"
 	"github.com/cybergarage/go-tracing/tracer"
 )
@@ -28,13 +32,35 @@ type Server interface {
 
 	// Config returns the server configuration.
 	Config() Config
+	// Stats returns the server statistics.
+	Stats() *Stats
 
 	// SetCommandHandler sets a user handler to handle user commands.
 	SetCommandHandler(handler UserCommandHandler)
 	// RegisterExexutor sets a command executor.
 	RegisterExexutor(cmd string, executor Executor)
+	// AddInterceptor adds an interceptor to run around every command executor in the added order.
+	AddInterceptor(interceptor Interceptor)
+	// SetSNICertificate sets a certificate for the specified SNI server name such as "redis.example.com" or "*.example.com".
+	SetSNICertificate(serverName string, cert tls.Certificate)
+	// SetSNICertificateFiles loads a certificate for the specified SNI server name from the files.
+	SetSNICertificateFiles(serverName string, certFile string, keyFile string) error
+	// RemoveSNICertificate removes the certificate for the specified SNI server name.
+	RemoveSNICertificate(serverName string)
+	// ReloadTLS reloads the certificates from the loaded files without dropping established connections.
+	ReloadTLS() error
+	// SetAdmissionFunc sets a function to allow or deny new client connections.
+	SetAdmissionFunc(fn AdmissionFunc)
 
 	Start() error
 	Stop() error
 	Restart() error
+	// Serve accepts client connections on the specified listener and handles them until the listener is closed.
+	Serve(l net.Listener) error
+	// ServeConn handles the specified client connection until the connection is closed.
+	ServeConn(conn net.Conn) error
+	// Shutdown stops the server gracefully waiting for in-flight commands until the context is done.
+	Shutdown(ctx context.Context) error
+	// Done returns a channel that is closed when the server is shut down by SHUTDOWN command.
+	Done() <-chan struct{}
 }
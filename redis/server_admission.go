@@ -0,0 +1,166 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-redis/redis/auth"
+	"github.com/cybergarage/go-redis/redis/proxy"
+)
+
+// AdmissionFunc represents a function to allow or deny a new client connection.
+// The connection is rejected with the returned error message if the function returns an error.
+type AdmissionFunc func(net.Conn) error
+
+// acceptLimiter represents a rate limiter for accepting connections.
+type acceptLimiter struct {
+	mutex *sync.Mutex
+	next  time.Time
+}
+
+// newAcceptLimiter returns a new accept rate limiter.
+func newAcceptLimiter() *acceptLimiter {
+	return &acceptLimiter{
+		mutex: &sync.Mutex{},
+		next:  time.Time{},
+	}
+}
+
+// Wait blocks until a next connection can be accepted within the specified rate per second.
+func (limiter *acceptLimiter) Wait(rate int) {
+	if rate <= 0 {
+		return
+	}
+
+	interval := time.Second / time.Duration(rate)
+
+	limiter.mutex.Lock()
+
+	now := time.Now()
+	if limiter.next.Before(now) {
+		limiter.next = now
+	}
+
+	wait := limiter.next.Sub(now)
+	limiter.next = limiter.next.Add(interval)
+
+	limiter.mutex.Unlock()
+
+	if 0 < wait {
+		time.Sleep(wait)
+	}
+}
+
+// SetAdmissionFunc sets a function to allow or deny new client connections.
+func (server *server) SetAdmissionFunc(fn AdmissionFunc) {
+	server.admissionFunc = fn
+}
+
+// admitConn adds the specified connection to the connection manager if the connection is allowed.
+func (server *server) admitConn(conn *Conn) error {
+	if server.isProtectedConn(conn) {
+		return ErrProtectedMode
+	}
+
+	if server.admissionFunc != nil {
+		if err := server.admissionFunc(conn); err != nil {
+			return err
+		}
+	}
+
+	return server.AddConnWithLimits(conn, server.MaxClients(), server.MaxClientsPerIP())
+}
+
+// isProtectedConn returns true if the specified connection should be refused by the protected mode.
+func (server *server) isProtectedConn(conn net.Conn) bool {
+	if !server.IsProtectedModeEnabled() {
+		return false
+	}
+
+	if server.hasCredentials() {
+		return false
+	}
+
+	return !isLoopbackConn(conn)
+}
+
+// hasCredentials returns true if a password or credentials are configured.
+func (server *server) hasCredentials() bool {
+	if _, ok := server.ConfigRequirePass(); ok {
+		return true
+	}
+
+	// A custom credential store is regarded as configured credentials.
+	if store := server.CredentialStore(); store != auth.CredentialStore(server) {
+		return true
+	}
+
+	return 0 < len(server.credStore)
+}
+
+// isLoopbackConn returns true if the specified connection comes from the loopback interface or a non-IP transport such as Unix domain sockets.
+// The physical peer address is checked instead of the client address reported by PROXY protocol headers.
+func isLoopbackConn(conn net.Conn) bool {
+	addr := peerAddr(conn)
+	if addr == nil {
+		return true
+	}
+
+	switch addr := addr.(type) {
+	case *net.TCPAddr:
+		return addr.IP.IsLoopback()
+	case *net.UnixAddr:
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		host = addr.String()
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return true
+	}
+
+	return ip.IsLoopback()
+}
+
+// rejectConn responds the specified error to the connection before closing it.
+func (server *server) rejectConn(conn net.Conn, err error) error {
+	return server.responseMessage(conn, NewErrorMessage(err))
+}
+
+// peerAddr returns the address of the physical peer of the specified connection unwrapping TLS and PROXY protocol connections.
+func peerAddr(conn net.Conn) net.Addr {
+	for {
+		switch c := conn.(type) {
+		case *Conn:
+			conn = c.Conn
+		case *peekedConn:
+			conn = c.Conn
+		case *tls.Conn:
+			conn = c.NetConn()
+		case *proxy.Conn:
+			return c.ProxyAddr()
+		default:
+			return conn.RemoteAddr()
+		}
+	}
+}
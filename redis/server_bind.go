@@ -0,0 +1,120 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/cybergarage/go-logger/log"
+)
+
+const (
+	bindOptionalPrefix = "-"
+	bindAnyIPv4        = "*"
+	bindAnyIPv6        = "::*"
+)
+
+// bindAddress represents an address to listen on.
+type bindAddress struct {
+	network  string
+	host     string
+	optional bool
+}
+
+// newBindAddress returns a bind address from the specified address such as "127.0.0.1", "-::1" or "*".
+func newBindAddress(addr string) bindAddress {
+	optional := strings.HasPrefix(addr, bindOptionalPrefix)
+	addr = strings.TrimPrefix(addr, bindOptionalPrefix)
+
+	switch addr {
+	case bindAnyIPv4:
+		return bindAddress{network: "tcp4", host: "0.0.0.0", optional: optional}
+	case bindAnyIPv6:
+		return bindAddress{network: "tcp6", host: "::", optional: optional}
+	}
+
+	network := "tcp"
+	if ip := net.ParseIP(addr); ip != nil {
+		if ip.To4() != nil {
+			network = "tcp4"
+		} else {
+			network = "tcp6"
+		}
+	}
+
+	return bindAddress{network: network, host: addr, optional: optional}
+}
+
+// String returns the string representation of the bind address.
+func (bind bindAddress) String() string {
+	return bind.host
+}
+
+// bindAddresses returns the addresses to listen on, Addr is used if no bind address is configured.
+func (server *server) bindAddresses() []bindAddress {
+	addrs := server.Bind()
+	if len(addrs) == 0 {
+		return []bindAddress{{network: "tcp", host: server.Addr, optional: false}}
+	}
+
+	binds := make([]bindAddress, len(addrs))
+	for n, addr := range addrs {
+		binds[n] = newBindAddress(addr)
+	}
+
+	return binds
+}
+
+// listenTCP listens on the specified port of all bind addresses.
+func (server *server) listenTCP(port int) ([]net.Listener, error) {
+	listeners := []net.Listener{}
+
+	for _, bind := range server.bindAddresses() {
+		addr := net.JoinHostPort(bind.host, strconv.Itoa(port))
+
+		l, err := net.Listen(bind.network, addr)
+		if err != nil {
+			if bind.optional {
+				log.Warnf("%s/%s (%s) skipped: %s", PackageName, Version, addr, err)
+				continue
+			}
+
+			closeTCPListeners(listeners)
+
+			return nil, err
+		}
+
+		log.Infof("%s/%s (%s) started", PackageName, Version, addr)
+
+		listeners = append(listeners, l)
+	}
+
+	return listeners, nil
+}
+
+// closeTCPListeners closes the specified listeners.
+func closeTCPListeners(listeners []net.Listener) error {
+	var err error
+
+	for _, l := range listeners {
+		if lerr := l.Close(); lerr != nil {
+			err = lerr
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cybergarage/go-redis/redis/proto"
+)
+
+// setParserLimits sets the current query buffer limits to the specified parser.
+func (server *server) setParserLimits(parser *proto.Parser) {
+	parser.SetMaxBulkLength(server.ProtoMaxBulkLen())
+	parser.SetMaxMultiBulkLength(server.ProtoMaxMultiBulkLen())
+	parser.SetMaxQueryLength(server.ClientQueryBufferLimit())
+}
+
+// isQueryLimitError returns true if the specified error is caused by the query buffer limits.
+func isQueryLimitError(err error) bool {
+	return errors.Is(err, proto.ErrInvalidBulkLength) ||
+		errors.Is(err, proto.ErrInvalidMultiBulkLength) ||
+		errors.Is(err, proto.ErrQueryBufferLimit)
+}
+
+// writeResponseMessage writes the response message to the connection within the output buffer limit of the client class.
+// The response is discarded if it exceeds the hard limit, and the write is aborted
+// if a response exceeding the soft limit is not sent within the soft duration.
+func (server *server) writeResponseMessage(conn *Conn, msg *Message) error {
+	if msg == nil {
+		msg = NewErrorMessage(ErrSystem)
+	}
+
+	bytes, err := msg.RESPBytes()
+	if err != nil {
+		return err
+	}
+
+	limit := server.ClientOutputBufferLimit(conn.ClientClass())
+	if 0 < limit.HardLimit && limit.HardLimit < len(bytes) {
+		return fmt.Errorf("%w (%s hard limit %d < %d)", ErrOutputBufferLimit, limit.Class, limit.HardLimit, len(bytes))
+	}
+
+	if 0 < limit.SoftLimit && limit.SoftLimit < len(bytes) && 0 < limit.SoftDuration {
+		err := conn.SetWriteDeadline(time.Now().Add(limit.SoftDuration))
+		if err != nil {
+			return err
+		}
+
+		defer conn.SetWriteDeadline(time.Time{})
+	}
+
+	_, err = conn.Write(bytes)
+	if err != nil {
+		if isIdleTimeoutError(err) {
+			return fmt.Errorf("%w (%s soft limit %d for %s)", ErrOutputBufferLimit, limit.Class, limit.SoftLimit, limit.SoftDuration)
+		}
+
+		return err
+	}
+
+	return nil
+}
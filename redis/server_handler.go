@@ -16,11 +16,8 @@ package redis
 
 import (
 	"strings"
-
-	"github.com/cybergarage/go-redis/redis/proto"
 )
 
-type Arguments = *proto.Array
 type Executor func(*Conn, string, Arguments) (*Message, error)
 type Executors map[string]Executor
 
@@ -32,14 +29,32 @@ func (server *server) executeCommand(conn *Conn, cmd string, args Arguments) (*M
 
 	upperCmd := strings.ToUpper(cmd)
 
-	cmdExecutor, ok := server.commandExecutors[upperCmd]
-	if !ok {
+	if _, ok := server.commandExecutors[upperCmd]; !ok {
 		return NewErrorNotSupportedMessage(cmd), nil
 	}
 
 	conn.StartSpan(upperCmd)
 	defer conn.FinishSpan()
 
+	// Nested commands such as sugar commands are not intercepted again.
+	if !conn.startCommand(conn.Span().Context(), server.CommandTimeout()) {
+		return server.dispatchCommand(conn, cmd, args)
+	}
+
+	defer conn.finishCommand()
+
+	return server.interceptCommand(conn, cmd, args, server.dispatchCommand)
+}
+
+// dispatchCommand runs the registered executor of the specified command.
+func (server *server) dispatchCommand(conn *Conn, cmd string, args Arguments) (*Message, error) {
+	upperCmd := strings.ToUpper(cmd)
+
+	cmdExecutor, ok := server.commandExecutors[upperCmd]
+	if !ok {
+		return NewErrorNotSupportedMessage(cmd), nil
+	}
+
 	if !conn.IsAuthrized() {
 		if upperCmd != "AUTH" {
 			return nil, ErrNotAuthrized
@@ -15,11 +15,14 @@
 package redis
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"io"
 	"net"
-	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/cybergarage/go-logger/log"
 	"github.com/cybergarage/go-redis/redis/auth"
@@ -34,13 +37,30 @@ type server struct {
 	tracer.Tracer
 
 	Addr                 string
-	portListener         net.Listener
-	tlsPortListener      net.Listener
+	portListeners        []net.Listener
+	tlsPortListeners     []net.Listener
+	unixListener         net.Listener
+	listeners            map[net.Listener]struct{}
+	listenerMutex        *sync.Mutex
 	tlsConfig            *tls.Config
+	activeTLSConfig      atomic.Pointer[tls.Config]
+	sniCerts             map[string]*sniCertificate
+	sniMutex             *sync.RWMutex
+	tlsReloadMutex       *sync.Mutex
+	tlsWatcherStopCh     chan struct{}
 	systemCommandHandler SystemCommandHandler
 	userCommandHandler   UserCommandHandler
 	commandExecutors     Executors
+	interceptors         []Interceptor
+	keyLock              *KeyLock
 	credStore            map[string]auth.Credential
+	admissionFunc        AdmissionFunc
+	acceptLimiter        *acceptLimiter
+	stats                *Stats
+	shuttingDown         atomic.Bool
+	shutdownCancel       context.CancelCauseFunc
+	shutdownMutex        *sync.Mutex
+	doneCh               chan struct{}
 }
 
 // NewServer returns a new server instance.
@@ -51,18 +71,42 @@ func NewServer() Server {
 		ConnManager:          NewConnManager(),
 		Tracer:               tracer.NullTracer,
 		Addr:                 "",
-		portListener:         nil,
-		tlsPortListener:      nil,
+		portListeners:        []net.Listener{},
+		tlsPortListeners:     []net.Listener{},
+		unixListener:         nil,
+		listeners:            map[net.Listener]struct{}{},
+		listenerMutex:        &sync.Mutex{},
 		tlsConfig:            nil,
+		activeTLSConfig:      atomic.Pointer[tls.Config]{},
+		sniCerts:             map[string]*sniCertificate{},
+		sniMutex:             &sync.RWMutex{},
+		tlsReloadMutex:       &sync.Mutex{},
+		tlsWatcherStopCh:     nil,
 		systemCommandHandler: nil,
 		userCommandHandler:   nil,
 		commandExecutors:     Executors{},
+		interceptors:         []Interceptor{},
+		keyLock:              NewKeyLock(),
 		credStore:            make(map[string]auth.Credential),
+		admissionFunc:        nil,
+		acceptLimiter:        newAcceptLimiter(),
+		stats:                newStats(),
+		shuttingDown:         atomic.Bool{},
+		shutdownCancel:       nil,
+		shutdownMutex:        &sync.Mutex{},
+		doneCh:               make(chan struct{}),
 	}
 
+	server.tlsConfig = server.newServerTLSConfig()
+
 	server.SetPort(DefaultPort)
+	server.SetMaxClients(DefaultMaxClients)
 	server.registerCoreExecutors()
+	server.registerBytesExecutors()
 	server.registerSugarExecutors()
+	server.registerScanExecutors()
+	server.registerSortExecutors()
+	server.registerSetAlgebraExecutors()
 	server.systemCommandHandler = server
 	server.SetCredentialStore(server)
 
@@ -79,6 +123,11 @@ func (server *server) Config() Config {
 	return server.serverConfig
 }
 
+// Stats returns the server statistics.
+func (server *server) Stats() *Stats {
+	return server.stats
+}
+
 // SetCommandHandler sets a user handler to handle user commands.
 func (server *server) SetCommandHandler(handler UserCommandHandler) {
 	server.userCommandHandler = handler
@@ -91,6 +140,20 @@ func (server *server) RegisterExexutor(cmd string, executor Executor) {
 
 // Start starts the server.
 func (server *server) Start() error {
+	server.setRequirePassCredential()
+
+	err := server.ConnManager.Start()
+	if err != nil {
+		return err
+	}
+
+	server.resetDone()
+
+	return server.listen()
+}
+
+// setRequirePassCredential sets a credential for the configured password.
+func (server *server) setRequirePassCredential() {
 	password, requirePass := server.ConfigRequirePass()
 	if requirePass {
 		cred := auth.NewCredential(
@@ -98,93 +161,97 @@ func (server *server) Start() error {
 		)
 		server.SetCredential(cred)
 	}
+}
 
-	err := server.ConnManager.Start()
+// Stop stops the server.
+func (server *server) Stop() error {
+	err := server.ConnManager.Stop()
 	if err != nil {
 		return err
 	}
 
-	err = server.open()
+	return server.close()
+}
+
+// Restart restarts the server.
+func (server *server) Restart() error {
+	err := server.Stop()
 	if err != nil {
 		return err
 	}
 
-	if server.IsPortEnabled() {
-		go server.serve()
-	}
-
-	if server.IsTLSPortEnabled() {
-		go server.tlsServe()
-	}
-
-	return nil
+	return server.Start()
 }
 
-// Stop stops the server.
-func (server *server) Stop() error {
-	err := server.ConnManager.Stop()
+// listen opens the listen sockets and starts handling client connections.
+func (server *server) listen() error {
+	err := server.open()
 	if err != nil {
 		return err
 	}
 
-	err = server.close()
-	if err != nil {
-		return err
+	for _, l := range server.portListeners {
+		go server.serve(l)
 	}
 
-	if server.IsPortEnabled() {
-		addr := net.JoinHostPort(server.Addr, strconv.Itoa(server.Port()))
-		log.Infof("%s/%s (%s) terminated", PackageName, Version, addr)
+	for _, l := range server.tlsPortListeners {
+		go server.tlsServe(l)
 	}
 
-	if server.IsTLSPortEnabled() {
-		addr := net.JoinHostPort(server.Addr, strconv.Itoa(server.TLSPort()))
-		log.Infof("%s/%s (%s) terminated", PackageName, Version, addr)
+	if server.unixListener != nil {
+		go server.serve(server.unixListener)
 	}
 
 	return nil
 }
 
-// Restart restarts the server.
-func (server *server) Restart() error {
-	err := server.Stop()
+// open opens the listen sockets, and closes the opened sockets if any of them cannot be opened.
+func (server *server) open() error {
+	err := server.openListeners()
 	if err != nil {
-		return err
+		return errors.Join(err, server.close())
 	}
 
-	return server.Start()
+	return nil
 }
 
-// open opens a listen socket.
-func (server *server) open() error {
+// openListeners starts the TLS watcher and opens the listen sockets.
+func (server *server) openListeners() error {
 	var err error
 
-	if server.IsPortEnabled() {
-		addr := net.JoinHostPort(server.Addr, strconv.Itoa(server.Port()))
+	if server.IsTLSPortEnabled() || server.IsTLSAutoDetectEnabled() {
+		server.tlsReloadMutex.Lock()
+		err = server.loadTLSConfig()
+		server.tlsReloadMutex.Unlock()
 
-		server.portListener, err = net.Listen("tcp", addr)
 		if err != nil {
 			return err
 		}
 
-		log.Infof("%s/%s (%s) started", PackageName, Version, addr)
+		server.startTLSWatcher()
 	}
 
-	if server.IsTLSPortEnabled() {
-		tlsConfig, err := server.TLSConfig()
+	if server.IsPortEnabled() {
+		server.portListeners, err = server.listenTCP(server.Port())
 		if err != nil {
 			return err
 		}
+	}
 
-		server.tlsConfig = tlsConfig
-		addr := net.JoinHostPort(server.Addr, strconv.Itoa(server.TLSPort()))
+	if server.IsTLSPortEnabled() {
+		server.tlsPortListeners, err = server.listenTCP(server.TLSPort())
+		if err != nil {
+			return err
+		}
+	}
 
-		server.tlsPortListener, err = net.Listen("tcp", addr)
+	if server.IsUnixSocketEnabled() {
+		server.unixListener, err = server.openUnixSocket()
 		if err != nil {
 			return err
 		}
 
-		log.Infof("%s/%s (%s) started", PackageName, Version, addr)
+		log.Infof("%s/%s (%s) started", PackageName, Version, server.UnixSocket())
 	}
 
 	return nil
@@ -192,70 +259,85 @@ func (server *server) open() error {
 
 // close closes a listening socket.
 func (server *server) close() error {
-	if server.portListener != nil {
-		err := server.portListener.Close()
-		if err != nil {
-			return err
-		}
+	server.stopTLSWatcher()
 
-		server.portListener = nil
+	err := server.closeListeners()
+	if err != nil {
+		return err
 	}
 
-	if server.tlsPortListener != nil {
-		err := server.tlsPortListener.Close()
+	for _, l := range append(server.portListeners, server.tlsPortListeners...) {
+		err := l.Close()
 		if err != nil {
 			return err
 		}
 
-		server.tlsPortListener = nil
+		log.Infof("%s/%s (%s) terminated", PackageName, Version, l.Addr().String())
 	}
 
-	return nil
-}
-
-// serve handles client connections.
-func (server *server) serve() error {
-	defer server.close()
+	server.portListeners = []net.Listener{}
+	server.tlsPortListeners = []net.Listener{}
 
-	l := server.portListener
-	for l != nil {
-		conn, err := l.Accept()
+	if server.unixListener != nil {
+		err := server.closeUnixSocket(server.unixListener)
 		if err != nil {
 			return err
 		}
 
-		go server.receive(conn, nil)
+		log.Infof("%s/%s (%s) terminated", PackageName, Version, server.UnixSocket())
+
+		server.unixListener = nil
 	}
 
 	return nil
 }
 
+// serve handles client connections.
+func (server *server) serve(l net.Listener) error {
+	return server.accept(l, server.receiveConn)
+}
+
 // tlsServe handles client connections with TLS.
-func (server *server) tlsServe() error {
-	defer server.close()
+func (server *server) tlsServe(l net.Listener) error {
+	return server.accept(l, server.receiveTLSConn)
+}
+
+// accept accepts client connections and handles them with the specified handler in new goroutines.
+// Temporary accept errors such as running out of file descriptors are retried with backoff.
+func (server *server) accept(l net.Listener, handler func(net.Conn) error) error {
+	var delay time.Duration
+
+	for {
+		server.acceptLimiter.Wait(server.AcceptRateLimit())
 
-	l := server.tlsPortListener
-	for l != nil {
 		conn, err := l.Accept()
 		if err != nil {
-			return err
-		}
+			if !isTemporaryError(err) {
+				return err
+			}
 
-		tlsConn := tls.Server(conn, server.tlsConfig)
-		if err := tlsConn.Handshake(); err != nil {
-			return err
-		}
+			delay = nextAcceptRetryDelay(delay)
+			log.Warnf("%s/%s (%s) accept error: %s; retrying in %v", PackageName, Version, l.Addr().String(), err, delay)
+			time.Sleep(delay)
 
-		ok, err := server.VerifyCertificate(tlsConn)
-		if !ok {
-			log.Error(err)
-			return err
+			continue
 		}
 
-		go server.receive(tlsConn, tlsConn)
+		delay = 0
+
+		server.acceptConn(conn)
+
+		go handler(conn)
 	}
+}
 
-	return nil
+// acceptConn prepares the specified accepted connection.
+func (server *server) acceptConn(conn net.Conn) {
+	server.stats.totalConns.Add(1)
+
+	if err := server.setKeepAlive(conn); err != nil {
+		log.Error(err)
+	}
 }
 
 // receive handles a client connection.
@@ -277,12 +359,17 @@ func (server *server) receive(conn net.Conn, tlsConn *tls.Conn) error {
 		}
 
 		if err != nil {
+			server.stats.tlsFailures.Add(1)
 			log.Error(err)
 			return errors.Join(err, handlerConn.Close())
 		}
 	}
 
-	server.AddConn(handlerConn)
+	if err := server.admitConn(handlerConn); err != nil {
+		server.stats.rejectedConns.Add(1)
+		log.Warnf("%s/%s (%s) rejected: %s", PackageName, Version, conn.RemoteAddr().String(), err)
+		return errors.Join(err, server.rejectConn(conn, err))
+	}
 
 	defer func() {
 		server.RemoveConn(handlerConn)
@@ -290,20 +377,47 @@ func (server *server) receive(conn net.Conn, tlsConn *tls.Conn) error {
 
 	log.Debugf("%s/%s (%s) accepted", PackageName, Version, conn.RemoteAddr().String())
 
-	parser := proto.NewParserWithReader(conn)
+	// Reads requests through the handler connection which keeps the data read while watching the client disconnection.
+	parser := proto.NewParserWithReader(handlerConn)
 
-	for {
+	for !server.isShuttingDown() {
 		span := server.StartSpan(PackageName)
+		span.Span().SetTag(clientAddressTag, conn.RemoteAddr().String())
 		handlerConn.SetSpanContext(span)
 
 		handlerConn.StartSpan("parse")
 
+		if err := server.setIdleDeadline(conn); err != nil {
+			log.Error(err)
+		}
+
+		server.setParserLimits(parser)
+
 		reqMsg, parserErr := parser.Next()
 
 		handlerConn.FinishSpan()
 
 		if parserErr != nil {
 			span.Span().Finish()
+
+			if isIdleTimeoutError(parserErr) {
+				if server.isShuttingDown() {
+					return nil
+				}
+
+				server.stats.idleTimeoutConns.Add(1)
+				log.Debugf("%s/%s (%s) closed by idle timeout", PackageName, Version, conn.RemoteAddr().String())
+
+				return nil
+			}
+
+			if isQueryLimitError(parserErr) {
+				server.stats.queryLimitConns.Add(1)
+				log.Warnf("%s/%s (%s) closed: %s", PackageName, Version, conn.RemoteAddr().String(), parserErr)
+
+				return errors.Join(parserErr, server.responseMessage(conn, NewErrorMessage(parserErr)))
+			}
+
 			log.Error(parserErr)
 
 			return parserErr
@@ -326,13 +440,27 @@ func (server *server) receive(conn net.Conn, tlsConn *tls.Conn) error {
 			}
 		}
 
+		// Closes the connection without any response if the quit request has no response.
+		if errors.Is(reqErr, ErrQuit) && resMsg == nil {
+			span.Span().Finish()
+			return nil
+		}
+
 		handlerConn.StartSpan("response")
 
-		resErr := server.responseMessage(conn, resMsg)
+		resErr := server.writeResponseMessage(handlerConn, resMsg)
 
 		handlerConn.FinishSpan()
 
 		if resErr != nil {
+			if errors.Is(resErr, ErrOutputBufferLimit) {
+				span.Span().Finish()
+				server.stats.outputLimitConns.Add(1)
+				log.Warnf("%s/%s (%s) closed: %s", PackageName, Version, conn.RemoteAddr().String(), resErr)
+
+				return resErr
+			}
+
 			log.Error(resErr)
 		}
 
@@ -414,5 +542,10 @@ func (server *server) handleArrayMessage(conn *Conn, arrayMsg *proto.Array) (*Me
 		return nil, err
 	}
 
-	return server.executeCommand(conn, cmd, arrayMsg)
+	args, err := newArgumentsWithArray(arrayMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	return server.executeCommand(conn, cmd, args)
 }
@@ -0,0 +1,52 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+// Interceptor represents a function to run around every command executor including AUTH and system commands.
+// The interceptor calls next to continue the command, or returns a response without calling next to stop it.
+// The arguments are a copy for the interceptor, so reading them does not consume the arguments passed to next,
+// and the interceptor can pass other arguments to next to rewrite the command.
+type Interceptor func(conn *Conn, cmd string, args Arguments, next Executor) (*Message, error)
+
+// AddInterceptor adds an interceptor to run around every command executor.
+// The interceptors run in the added order, so the first added interceptor is the outermost one.
+func (server *server) AddInterceptor(interceptor Interceptor) {
+	server.interceptors = append(server.interceptors, interceptor)
+}
+
+// interceptCommand runs the added interceptors around the specified executor.
+func (server *server) interceptCommand(conn *Conn, cmd string, args Arguments, executor Executor) (*Message, error) {
+	for n := len(server.interceptors) - 1; 0 <= n; n-- {
+		executor = newInterceptedExecutor(server.interceptors[n], executor)
+	}
+
+	return executor(conn, cmd, args)
+}
+
+// newInterceptedExecutor returns an executor which runs the specified interceptor before the next executor.
+func newInterceptedExecutor(interceptor Interceptor, next Executor) Executor {
+	return func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		interceptorArgs := args.Copy()
+
+		return interceptor(conn, cmd, interceptorArgs, func(conn *Conn, cmd string, nextArgs Arguments) (*Message, error) {
+			// Passes the unread arguments if the interceptor forwards its copy.
+			if nextArgs == interceptorArgs {
+				nextArgs = args
+			}
+
+			return next(conn, cmd, nextArgs)
+		})
+	}
+}
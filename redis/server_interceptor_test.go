@@ -0,0 +1,117 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+)
+
+// testCommandHandler is a user command handler which has no command implementations.
+type testCommandHandler struct {
+	UserCommandHandler
+}
+
+func TestServerInterceptor(t *testing.T) {
+	server, ok := NewServer().(*server)
+	if !ok {
+		t.Error("invalid server")
+		return
+	}
+
+	server.SetCommandHandler(&testCommandHandler{UserCommandHandler: nil})
+
+	server.RegisterExexutor("JOIN", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		strs := []string{}
+
+		for {
+			str, err := args.NextString()
+			if err != nil {
+				break
+			}
+
+			strs = append(strs, str)
+		}
+
+		return NewBulkMessage(strings.Join(strs, " ")), nil
+	})
+
+	calls := []string{}
+
+	// Reads all arguments and forwards them to the next executor.
+	server.AddInterceptor(func(conn *Conn, cmd string, args Arguments, next Executor) (*Message, error) {
+		msgs, err := args.NextMessages()
+		if err != nil {
+			return nil, err
+		}
+
+		calls = append(calls, cmd+" "+strings.Repeat("*", len(msgs)))
+
+		return next(conn, cmd, args)
+	})
+
+	// Rewrites the arguments with a key prefix.
+	server.AddInterceptor(func(conn *Conn, cmd string, args Arguments, next Executor) (*Message, error) {
+		calls = append(calls, "prefix")
+
+		key, err := args.NextString()
+		if err != nil {
+			return nil, err
+		}
+
+		msgs, err := args.NextMessages()
+		if err != nil {
+			return nil, err
+		}
+
+		return next(conn, cmd, NewArguments(append([]*Message{NewBulkMessage("app:" + key)}, msgs...)...))
+	})
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+
+	conn := newConnWith(serverConn, nil)
+	defer conn.Close()
+
+	conn.SetAuthrized(true)
+
+	res, err := server.executeCommand(conn, "JOIN", NewStringArguments("key", "val"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if str, _ := res.String(); str != "app:key val" {
+		t.Errorf("%q != %q", str, "app:key val")
+	}
+
+	if expected := "JOIN ** prefix"; strings.Join(calls, " ") != expected {
+		t.Errorf("%q != %q", strings.Join(calls, " "), expected)
+	}
+
+	// Unauthorized commands are intercepted too.
+	conn.SetAuthrized(false)
+
+	_, err = server.executeCommand(conn, "JOIN", NewStringArguments("key"))
+	if !errors.Is(err, ErrNotAuthrized) {
+		t.Errorf("%v != %v", err, ErrNotAuthrized)
+	}
+
+	if n := len(calls); n != 4 {
+		t.Errorf("%d != %d", n, 4)
+	}
+}
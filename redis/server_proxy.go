@@ -0,0 +1,105 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/cybergarage/go-logger/log"
+	"github.com/cybergarage/go-redis/redis/proxy"
+)
+
+// acceptProxyConn reads a PROXY protocol header from the specified connection if the protocol is enabled and the peer is a trusted proxy.
+// Connections from untrusted peers are returned as is, so their PROXY protocol headers are handled as invalid requests.
+func (server *server) acceptProxyConn(conn net.Conn) (net.Conn, error) {
+	if !server.IsProxyProtocolEnabled() {
+		return conn, nil
+	}
+
+	if !server.isTrustedProxy(conn.RemoteAddr()) {
+		log.Warnf("%s/%s (%s) untrusted proxy", PackageName, Version, conn.RemoteAddr().String())
+		return conn, nil
+	}
+
+	err := conn.SetReadDeadline(time.Now().Add(DefaultProxyHeaderTimeout))
+	if err != nil {
+		return nil, err
+	}
+
+	proxyConn, err := proxy.NewConn(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	err = conn.SetReadDeadline(time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	return proxyConn, nil
+}
+
+// isTrustedProxy returns true if the specified address is allowed to send PROXY protocol headers.
+func (server *server) isTrustedProxy(addr net.Addr) bool {
+	var ip net.IP
+
+	switch addr := addr.(type) {
+	case *net.TCPAddr:
+		ip = addr.IP
+	default:
+		return false
+	}
+
+	// Only local proxies are trusted unless trusted sources are configured.
+	sources := server.ProxyProtocolTrustedSources()
+	if len(sources) == 0 {
+		return ip.IsLoopback()
+	}
+
+	for _, source := range sources {
+		if strings.Contains(source, "/") {
+			_, ipNet, err := net.ParseCIDR(source)
+			if err == nil && ipNet.Contains(ip) {
+				return true
+			}
+
+			continue
+		}
+
+		if ip.Equal(net.ParseIP(source)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// receiveConn handles a plain client connection after reading the PROXY protocol header, and detects TLS connections if enabled.
+func (server *server) receiveConn(conn net.Conn) error {
+	proxyConn, err := server.acceptProxyConn(conn)
+	if err != nil {
+		log.Warnf("%s/%s (%s) closed: %s", PackageName, Version, conn.RemoteAddr().String(), err)
+		return errors.Join(err, conn.Close())
+	}
+
+	if server.IsTLSAutoDetectEnabled() {
+		return server.receiveAutoDetectConn(proxyConn)
+	}
+
+	return server.receive(proxyConn, nil)
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"crypto/tls"
+	"net"
+)
+
+// Serve accepts client connections on the specified listener and handles them.
+// Serve blocks until the listener fails or is closed, and the listener is closed by Stop or Shutdown.
+// Serve returns nil if the listener is closed by the server.
+func (server *server) Serve(l net.Listener) error {
+	server.setRequirePassCredential()
+
+	server.addListener(l)
+
+	err := server.serve(l)
+
+	if !server.removeListener(l) {
+		return nil
+	}
+
+	return err
+}
+
+// ServeConn handles the specified client connection until the connection is closed.
+// ServeConn can be used with in-process connections such as net.Pipe, and performs the TLS handshake for a TLS connection.
+// The PROXY protocol header is read from a non-TLS connection if the protocol is enabled.
+func (server *server) ServeConn(conn net.Conn) error {
+	server.setRequirePassCredential()
+
+	server.acceptConn(conn)
+
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return server.receiveConn(conn)
+	}
+
+	err := server.handshake(tlsConn)
+	if err != nil {
+		return err
+	}
+
+	return server.receive(tlsConn, tlsConn)
+}
+
+// addListener adds the specified caller-supplied listener to be closed by the server.
+func (server *server) addListener(l net.Listener) {
+	server.listenerMutex.Lock()
+	defer server.listenerMutex.Unlock()
+
+	server.listeners[l] = struct{}{}
+}
+
+// removeListener removes the specified caller-supplied listener, and returns false if the listener has been closed by the server.
+func (server *server) removeListener(l net.Listener) bool {
+	server.listenerMutex.Lock()
+	defer server.listenerMutex.Unlock()
+
+	_, ok := server.listeners[l]
+	delete(server.listeners, l)
+
+	return ok
+}
+
+// closeListeners closes all caller-supplied listeners.
+func (server *server) closeListeners() error {
+	server.listenerMutex.Lock()
+	defer server.listenerMutex.Unlock()
+
+	var err error
+
+	for l := range server.listeners {
+		if lerr := l.Close(); lerr != nil {
+			err = lerr
+		}
+
+		delete(server.listeners, l)
+	}
+
+	return err
+}
@@ -0,0 +1,221 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cybergarage/go-logger/log"
+	"github.com/cybergarage/go-redis/redis/auth"
+)
+
+const (
+	shutdownPollInterval = 100 * time.Millisecond
+)
+
+// Shutdown stops the server gracefully. Shutdown keeps serving clients during the shutdown abort window,
+// in which SHUTDOWN ABORT cancels the shutdown and Shutdown returns ErrShutdownAborted.
+// Then Shutdown stops accepting new connections, lets in-flight commands finish and their responses flush,
+// and waits for all connections to be closed.
+// If the context expires before that, Shutdown closes the remaining connections and returns the context error.
+func (server *server) Shutdown(ctx context.Context) error {
+	err := server.waitShutdownAbort(ctx)
+	if err != nil {
+		return err
+	}
+
+	server.shuttingDown.Store(true)
+	defer server.shuttingDown.Store(false)
+
+	err = server.close()
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(shutdownPollInterval)
+	defer ticker.Stop()
+
+	for 0 < server.NumConns() {
+		// Wakes up the connections waiting for a next request.
+		for _, conn := range server.Conns() {
+			conn.SetReadDeadline(time.Now())
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Warnf("%s/%s closing %d connections forcibly", PackageName, Version, server.NumConns())
+
+			return errors.Join(ctx.Err(), server.Stop())
+		case <-ticker.C:
+		}
+	}
+
+	return server.Stop()
+}
+
+// waitShutdownAbort keeps serving clients until the shutdown abort window passes or the context expires,
+// and returns ErrShutdownAborted if SHUTDOWN ABORT cancels the shutdown in the meantime.
+func (server *server) waitShutdownAbort(ctx context.Context) error {
+	window := server.ShutdownAbortWindow()
+	if window <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	server.shutdownMutex.Lock()
+	server.shutdownCancel = cancel
+	server.shutdownMutex.Unlock()
+
+	defer func() {
+		server.shutdownMutex.Lock()
+		server.shutdownCancel = nil
+		server.shutdownMutex.Unlock()
+	}()
+
+	timer := time.NewTimer(window)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		if cause := context.Cause(ctx); errors.Is(cause, ErrShutdownAborted) {
+			return cause
+		}
+	case <-timer.C:
+	}
+
+	return nil
+}
+
+// isShuttingDown returns true if the server is shutting down gracefully.
+func (server *server) isShuttingDown() bool {
+	return server.shuttingDown.Load()
+}
+
+// abortShutdown aborts the current graceful shutdown, and returns false if no shutdown is in progress.
+func (server *server) abortShutdown() bool {
+	server.shutdownMutex.Lock()
+	defer server.shutdownMutex.Unlock()
+
+	if server.shutdownCancel == nil {
+		return false
+	}
+
+	server.shutdownCancel(ErrShutdownAborted)
+
+	return true
+}
+
+// Done returns a channel that is closed when the server is shut down by SHUTDOWN command.
+func (server *server) Done() <-chan struct{} {
+	server.shutdownMutex.Lock()
+	defer server.shutdownMutex.Unlock()
+
+	return server.doneCh
+}
+
+// resetDone renews the done channel if the channel has been closed.
+func (server *server) resetDone() {
+	server.shutdownMutex.Lock()
+	defer server.shutdownMutex.Unlock()
+
+	select {
+	case <-server.doneCh:
+		server.doneCh = make(chan struct{})
+	default:
+	}
+}
+
+// closeDone closes the done channel.
+func (server *server) closeDone() {
+	server.shutdownMutex.Lock()
+	defer server.shutdownMutex.Unlock()
+
+	select {
+	case <-server.doneCh:
+	default:
+		close(server.doneCh)
+	}
+}
+
+// shutdown handles SHUTDOWN command.
+func (server *server) shutdown(conn *Conn, opt ShutdownOption) (*Message, error) {
+	if opt.ABORT {
+		if !server.abortShutdown() {
+			return nil, ErrNoShutdownInProgress
+		}
+
+		return NewOKMessage(), nil
+	}
+
+	if handler, ok := server.userCommandHandler.(PersistenceHandler); ok && !opt.NOSAVE {
+		err := handler.Save(conn, opt)
+		if err != nil {
+			log.Error(err)
+
+			if !opt.FORCE {
+				return nil, ErrShutdownSave
+			}
+		}
+	}
+
+	go func() {
+		var err error
+
+		if opt.NOW {
+			err = server.Stop()
+		} else {
+			ctx, cancel := context.WithTimeout(context.Background(), server.ShutdownTimeout())
+			defer cancel()
+
+			err = server.Shutdown(ctx)
+		}
+
+		if errors.Is(err, ErrShutdownAborted) {
+			return
+		}
+
+		if err != nil {
+			log.Error(err)
+		}
+
+		server.closeDone()
+	}()
+
+	return nil, ErrQuit
+}
+
+// isAdminConn returns true if the connection is authenticated as the default user or an user in the admin group.
+func (server *server) isAdminConn(conn *Conn) bool {
+	username, ok := conn.UserName()
+	if !ok || username == DefaultUser {
+		return true
+	}
+
+	q, err := auth.NewQuery(auth.WithQueryUsername(username))
+	if err != nil {
+		return false
+	}
+
+	cred, ok, err := server.CredentialStore().LookupCredential(q)
+	if err != nil || !ok {
+		return false
+	}
+
+	return cred.Group() == AdminGroup
+}
@@ -15,7 +15,16 @@
 package redis
 
 import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
+	"time"
 )
 
 func TestServer(t *testing.T) {
@@ -33,3 +42,647 @@ func TestServer(t *testing.T) {
 		return
 	}
 }
+
+func TestServerIdleTimeout(t *testing.T) {
+	const testPort = 6380
+
+	server := NewServer()
+	server.SetPort(testPort)
+	server.SetIdleTimeout(time.Second)
+
+	err := server.Start()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer server.Stop()
+
+	conn, err := net.Dial("tcp", net.JoinHostPort(LocalHost, strconv.Itoa(testPort)))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer conn.Close()
+
+	err = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	_, err = conn.Read(make([]byte, 1))
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("%v != %v", err, io.EOF)
+		return
+	}
+
+	if n := server.Stats().IdleTimeoutConnections(); n != 1 {
+		t.Errorf("%d != %d", n, 1)
+	}
+}
+
+func TestServerShutdown(t *testing.T) {
+	const testPort = 6381
+
+	server := NewServer()
+	server.SetPort(testPort)
+
+	err := server.Start()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	conn, err := net.Dial("tcp", net.JoinHostPort(LocalHost, strconv.Itoa(testPort)))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("*1\r\n$4\r\nPING\r\n"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	_, err = conn.Read(make([]byte, 64))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = server.Shutdown(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	_, err = conn.Read(make([]byte, 1))
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("%v != %v", err, io.EOF)
+	}
+}
+
+func TestServerShutdownAbort(t *testing.T) {
+	const testPort = 6388
+
+	server := NewServer()
+	server.SetPort(testPort)
+	server.SetShutdownAbortWindow(10 * time.Second)
+	server.SetCommandHandler(&testCommandHandler{UserCommandHandler: nil})
+
+	err := server.Start()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer server.Stop()
+
+	conn, err := net.Dial("tcp", net.JoinHostPort(LocalHost, strconv.Itoa(testPort)))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+
+	shutdownErr := make(chan error, 1)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		shutdownErr <- server.Shutdown(ctx)
+	}()
+
+	// Retries until the shutdown is pending while the existing connection keeps being served.
+	res := ""
+	for range 50 {
+		res = testServerRequest(t, conn, reader, "*2\r\n$8\r\nSHUTDOWN\r\n$5\r\nABORT\r\n")
+		if res == "+OK\r\n" {
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if res != "+OK\r\n" {
+		t.Errorf("%q != %q", res, "+OK\r\n")
+		return
+	}
+
+	select {
+	case err := <-shutdownErr:
+		if !errors.Is(err, ErrShutdownAborted) {
+			t.Errorf("%v != %v", err, ErrShutdownAborted)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("shutdown is not aborted")
+		return
+	}
+
+	// The existing connection and new connections are served after the abort.
+	if res := testServerRequest(t, conn, reader, "*1\r\n$4\r\nPING\r\n"); res != "+PONG\r\n" {
+		t.Errorf("%q != %q", res, "+PONG\r\n")
+	}
+
+	newConn, err := net.Dial("tcp", net.JoinHostPort(LocalHost, strconv.Itoa(testPort)))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer newConn.Close()
+
+	if res := testServerRequest(t, newConn, bufio.NewReader(newConn), "*1\r\n$4\r\nPING\r\n"); res != "+PONG\r\n" {
+		t.Errorf("%q != %q", res, "+PONG\r\n")
+	}
+}
+
+// testServerRequest writes the request to the connection and returns the first line of the response.
+func testServerRequest(t *testing.T, conn net.Conn, reader *bufio.Reader, req string) string {
+	t.Helper()
+
+	_, err := conn.Write([]byte(req))
+	if err != nil {
+		t.Error(err)
+		return ""
+	}
+
+	res, err := reader.ReadString('\n')
+	if err != nil {
+		t.Error(err)
+		return ""
+	}
+
+	return res
+}
+
+func TestServerUnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "redis.sock")
+
+	// Leave a stale socket file which no server is listening on.
+	stale, err := net.Listen("unix", path)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	server := NewServer()
+	server.SetPort(0)
+	server.SetUnixSocket(path)
+	server.SetUnixSocketPerm(0o700)
+
+	err = server.Start()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if perm := fi.Mode().Perm(); perm != 0o700 {
+		t.Errorf("%o != %o", perm, 0o700)
+	}
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer conn.Close()
+
+	testServerPing(t, conn)
+
+	err = server.Stop()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("%v != %v", err, os.ErrNotExist)
+	}
+}
+
+func TestServerServe(t *testing.T) {
+	server := NewServer()
+
+	l, err := net.Listen("tcp", net.JoinHostPort(LocalHost, "0"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- server.Serve(l)
+	}()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer conn.Close()
+
+	testServerPing(t, conn)
+
+	err = server.Stop()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Serve has not returned")
+	}
+}
+
+func TestServerServeConn(t *testing.T) {
+	server := NewServer()
+
+	clientConn, serverConn := net.Pipe()
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- server.ServeConn(serverConn)
+	}()
+
+	testServerPing(t, clientConn)
+
+	clientConn.Close()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("ServeConn has not returned")
+	}
+}
+
+func testServerPing(t *testing.T, conn net.Conn) {
+	t.Helper()
+
+	err := conn.SetDeadline(time.Now().Add(5 * time.Second))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	_, err = conn.Write([]byte("*1\r\n$4\r\nPING\r\n"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// No command handler is set, so the request is answered with an error reply.
+	res, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if expected := "-" + NewErrNotSupported("PING").Error() + "\r\n"; res != expected {
+		t.Errorf("%q != %q", res, expected)
+	}
+}
+
+func TestServerBind(t *testing.T) {
+	const testPort = 6383
+
+	server := NewServer()
+	server.SetPort(testPort)
+	// 192.0.2.1 is reserved for documentation and is not assigned to any interfaces.
+	server.SetBind("127.0.0.1", "-192.0.2.1")
+
+	err := server.Start()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer server.Stop()
+
+	conn, err := net.Dial("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(testPort)))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer conn.Close()
+
+	testServerPing(t, conn)
+}
+
+func TestServerOpenFailure(t *testing.T) {
+	const testPort = 6386
+
+	server := NewServer()
+	server.SetPort(testPort)
+	server.SetBind("127.0.0.1")
+	server.SetUnixSocket(filepath.Join(t.TempDir(), "missing", "redis.sock"))
+
+	if err := server.Start(); err == nil {
+		server.Stop()
+		t.Error("the server should not be started")
+
+		return
+	}
+
+	// The listen port opened before the failure is closed.
+	l, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(testPort)))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	l.Close()
+}
+
+type testRemoteConn struct {
+	net.Conn
+	remoteAddr net.Addr
+}
+
+func (conn *testRemoteConn) RemoteAddr() net.Addr {
+	return conn.remoteAddr
+}
+
+func TestServerProtectedMode(t *testing.T) {
+	remoteAddr := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 6379, Zone: ""}
+
+	for _, protected := range []bool{true, false} {
+		server := NewServer()
+		server.SetProtectedMode(protected)
+
+		clientConn, serverConn := net.Pipe()
+
+		go server.ServeConn(&testRemoteConn{Conn: serverConn, remoteAddr: remoteAddr})
+
+		if !protected {
+			testServerPing(t, clientConn)
+			clientConn.Close()
+
+			continue
+		}
+
+		res, err := bufio.NewReader(clientConn).ReadString('\n')
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if expected := "-" + ErrProtectedMode.Error() + "\r\n"; res != expected {
+			t.Errorf("%q != %q", res, expected)
+		}
+
+		clientConn.Close()
+	}
+}
+
+func TestServerProxyProtocol(t *testing.T) {
+	ping := "*1\r\n$4\r\nPING\r\n"
+
+	tests := []struct {
+		sources  []string
+		peer     string
+		header   string
+		expected error
+	}{
+		{
+			sources:  []string{"127.0.0.0/8"},
+			peer:     "127.0.0.1",
+			header:   "PROXY TCP4 198.51.100.1 192.0.2.2 56324 6379\r\n",
+			expected: NewErrNotSupported("PING"),
+		},
+		// Protected mode checks the proxy address instead of the client address.
+		{
+			sources:  []string{"192.0.2.0/24"},
+			peer:     "192.0.2.1",
+			header:   "PROXY TCP4 127.0.0.1 192.0.2.2 56324 6379\r\n",
+			expected: ErrProtectedMode,
+		},
+		// Untrusted peers cannot spoof client addresses.
+		{
+			sources:  []string{"192.0.2.0/24"},
+			peer:     "203.0.113.1",
+			header:   "PROXY TCP4 127.0.0.1 192.0.2.2 56324 6379\r\n",
+			expected: ErrProtectedMode,
+		},
+		// Only loopback proxies are trusted if no trusted sources are configured.
+		{
+			sources:  []string{},
+			peer:     "127.0.0.1",
+			header:   "PROXY TCP4 198.51.100.1 192.0.2.2 56324 6379\r\n",
+			expected: NewErrNotSupported("PING"),
+		},
+	}
+
+	for _, test := range tests {
+		server := NewServer()
+		server.SetProxyProtocol(true)
+		server.SetProxyProtocolTrustedSources(test.sources...)
+
+		clientConn, serverConn := net.Pipe()
+		remoteAddr := &net.TCPAddr{IP: net.ParseIP(test.peer), Port: 6379, Zone: ""}
+
+		go server.ServeConn(&testRemoteConn{Conn: serverConn, remoteAddr: remoteAddr})
+
+		go clientConn.Write([]byte(test.header + ping))
+
+		res, err := bufio.NewReader(clientConn).ReadString('\n')
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if expected := "-" + test.expected.Error() + "\r\n"; res != expected {
+			t.Errorf("%s: %q != %q", test.peer, res, expected)
+		}
+
+		clientConn.Close()
+	}
+}
+
+func TestServerTrustedProxy(t *testing.T) {
+	tests := []struct {
+		sources  []string
+		peer     string
+		expected bool
+	}{
+		{[]string{}, "127.0.0.1", true},
+		{[]string{}, "192.0.2.1", false},
+		{[]string{"192.0.2.0/24"}, "192.0.2.1", true},
+		{[]string{"192.0.2.0/24"}, "127.0.0.1", false},
+		{[]string{"203.0.113.1"}, "203.0.113.1", true},
+	}
+
+	for _, test := range tests {
+		server, ok := NewServer().(*server)
+		if !ok {
+			t.Error("invalid server")
+			return
+		}
+
+		server.SetProxyProtocolTrustedSources(test.sources...)
+
+		addr := &net.TCPAddr{IP: net.ParseIP(test.peer), Port: 6379, Zone: ""}
+		if trusted := server.isTrustedProxy(addr); trusted != test.expected {
+			t.Errorf("%v %s: %t != %t", test.sources, test.peer, trusted, test.expected)
+		}
+	}
+}
+
+type testTemporaryError struct{}
+
+func (testTemporaryError) Error() string   { return "temporary error" }
+func (testTemporaryError) Timeout() bool   { return false }
+func (testTemporaryError) Temporary() bool { return true }
+
+type testFlakyListener struct {
+	net.Listener
+	errs []error
+}
+
+func (l *testFlakyListener) Accept() (net.Conn, error) {
+	if 0 < len(l.errs) {
+		err := l.errs[0]
+		l.errs = l.errs[1:]
+
+		return nil, err
+	}
+
+	return l.Listener.Accept()
+}
+
+func TestServerAcceptRetry(t *testing.T) {
+	l, err := net.Listen("tcp", net.JoinHostPort(LocalHost, "0"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	server := NewServer()
+
+	flakyListener := &testFlakyListener{
+		Listener: l,
+		errs:     []error{testTemporaryError{}, testTemporaryError{}},
+	}
+
+	go server.Serve(flakyListener)
+
+	defer server.Stop()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer conn.Close()
+
+	testServerPing(t, conn)
+}
+
+func TestServerConfigGetPattern(t *testing.T) {
+	server, ok := NewServer().(*server)
+	if !ok {
+		t.Error("invalid server")
+		return
+	}
+
+	server.SetMaxClientsPerIP(10)
+
+	res, err := server.ConfigGet(nil, []string{"MAXCLIENTS*"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	array, err := res.Array()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// maxclients and maxclients-per-ip
+	if n := array.Size(); n != 4 {
+		t.Errorf("%d != %d", n, 4)
+	}
+}
+
+func TestServerConfigSetValidation(t *testing.T) {
+	server, ok := NewServer().(*server)
+	if !ok {
+		t.Error("invalid server")
+		return
+	}
+
+	server.SetMaxClients(100)
+
+	invalidParams := []map[string]string{
+		{"maxclients": "abc"},
+		{"maxclients": "-1"},
+		{"proto-max-bulk-len": "1xb"},
+		{"client-query-buffer-limit": "-1mb"},
+		{"client-output-buffer-limit": "normal 0 0"},
+		{"client-output-buffer-limit": "unknown 0 0 0"},
+		{"timeout": "-1"},
+		{"timeout": "abc"},
+		// No parameters are changed if any of them is invalid.
+		{"maxclients": "200", "proto-max-bulk-len": "abc"},
+	}
+
+	for _, params := range invalidParams {
+		if _, err := server.ConfigSet(nil, params); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("%v: %v != %v", params, err, ErrInvalidArgument)
+		}
+	}
+
+	if n := server.MaxClients(); n != 100 {
+		t.Errorf("%d != %d", n, 100)
+	}
+
+	_, err := server.ConfigSet(nil, map[string]string{"maxclients": "200", "proto-max-bulk-len": "1mb"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if n := server.MaxClients(); n != 200 {
+		t.Errorf("%d != %d", n, 200)
+	}
+
+	if n := server.ProtoMaxBulkLen(); n != 1<<20 {
+		t.Errorf("%d != %d", n, 1<<20)
+	}
+}
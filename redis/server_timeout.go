@@ -0,0 +1,76 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"errors"
+	"net"
+	"os"
+	"time"
+)
+
+// setKeepAlive sets the TCP keepalive period to the specified connection.
+func (server *server) setKeepAlive(conn net.Conn) error {
+	tcpConn, ok := conn.(*net.TCPConn)
+	if !ok {
+		return nil
+	}
+
+	period := server.TCPKeepAlive()
+	if period <= 0 {
+		return tcpConn.SetKeepAlive(false)
+	}
+
+	err := tcpConn.SetKeepAlive(true)
+	if err != nil {
+		return err
+	}
+
+	return tcpConn.SetKeepAlivePeriod(period)
+}
+
+// setIdleDeadline sets the read deadline for the next request to the specified connection.
+func (server *server) setIdleDeadline(conn net.Conn) error {
+	timeout := server.IdleTimeout()
+	if timeout <= 0 {
+		return conn.SetReadDeadline(time.Time{})
+	}
+
+	return conn.SetReadDeadline(time.Now().Add(timeout))
+}
+
+// isTemporaryError returns true if the specified accept error is temporary and the accept can be retried.
+func isTemporaryError(err error) bool {
+	var tempErr interface{ Temporary() bool }
+	if errors.As(err, &tempErr) {
+		return tempErr.Temporary()
+	}
+
+	return false
+}
+
+// nextAcceptRetryDelay returns the next delay to retry the accept doubling the specified delay.
+func nextAcceptRetryDelay(delay time.Duration) time.Duration {
+	if delay <= 0 {
+		return minAcceptRetryDelay
+	}
+
+	return min(delay*2, maxAcceptRetryDelay)
+}
+
+// isIdleTimeoutError returns true if the specified error is caused by the idle deadline.
+func isIdleTimeoutError(err error) bool {
+	return errors.Is(err, os.ErrDeadlineExceeded)
+}
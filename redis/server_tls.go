@@ -0,0 +1,346 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"maps"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cybergarage/go-logger/log"
+)
+
+// sniCertificate represents a certificate selected by the SNI server name.
+type sniCertificate struct {
+	cert     *tls.Certificate
+	certFile string
+	keyFile  string
+}
+
+// receiveTLSConn handles a client connection after reading the PROXY protocol header and performing the TLS handshake.
+func (server *server) receiveTLSConn(conn net.Conn) error {
+	proxyConn, err := server.acceptProxyConn(conn)
+	if err != nil {
+		log.Warnf("%s/%s (%s) closed: %s", PackageName, Version, conn.RemoteAddr().String(), err)
+		return errors.Join(err, conn.Close())
+	}
+
+	tlsConn := tls.Server(proxyConn, server.tlsConfig)
+
+	err = server.handshake(tlsConn)
+	if err != nil {
+		return err
+	}
+
+	return server.receive(tlsConn, tlsConn)
+}
+
+// handshake performs the TLS handshake within the handshake timeout, and closes the connection if the handshake fails.
+func (server *server) handshake(tlsConn *tls.Conn) error {
+	ctx := context.Background()
+
+	if timeout := server.TLSHandshakeTimeout(); 0 < timeout {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err := tlsConn.HandshakeContext(ctx)
+	if err != nil {
+		server.stats.tlsFailures.Add(1)
+		log.Warnf("%s/%s (%s) TLS handshake failed: %s", PackageName, Version, tlsConn.RemoteAddr().String(), err)
+
+		return errors.Join(err, tlsConn.Close())
+	}
+
+	return nil
+}
+
+// SetSNICertificate sets a certificate for the specified SNI server name such as "redis.example.com" or "*.example.com".
+func (server *server) SetSNICertificate(serverName string, cert tls.Certificate) {
+	server.sniMutex.Lock()
+	defer server.sniMutex.Unlock()
+
+	server.sniCerts[strings.ToLower(serverName)] = &sniCertificate{
+		cert:     &cert,
+		certFile: "",
+		keyFile:  "",
+	}
+}
+
+// SetSNICertificateFiles loads a certificate for the specified SNI server name from the files, and the files are reloaded by ReloadTLS.
+func (server *server) SetSNICertificateFiles(serverName string, certFile string, keyFile string) error {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+
+	server.sniMutex.Lock()
+	defer server.sniMutex.Unlock()
+
+	server.sniCerts[strings.ToLower(serverName)] = &sniCertificate{
+		cert:     &cert,
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+
+	return nil
+}
+
+// RemoveSNICertificate removes the certificate for the specified SNI server name.
+func (server *server) RemoveSNICertificate(serverName string) {
+	server.sniMutex.Lock()
+	defer server.sniMutex.Unlock()
+
+	delete(server.sniCerts, strings.ToLower(serverName))
+}
+
+// ReloadTLS reloads the server certificate, key, root certificates and SNI certificates from the loaded files.
+// The reloaded certificates are used for new connections, and established connections are not affected.
+// Reloads are serialized, and the certificates are not changed if the certificate and key files do not match.
+func (server *server) ReloadTLS() error {
+	server.tlsReloadMutex.Lock()
+	defer server.tlsReloadMutex.Unlock()
+
+	certFile := server.TLSCertFile()
+	keyFile := server.TLSKeyFile()
+
+	// Verifies the pair before changing the certificate not to pair it with a mismatched key.
+	if 0 < len(certFile) && 0 < len(keyFile) {
+		_, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	if 0 < len(certFile) {
+		err := server.SetServerCertFile(certFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	if 0 < len(keyFile) {
+		err := server.SetServerKeyFile(keyFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	if files := server.TLSCACertFiles(); 0 < len(files) {
+		err := server.SetRootCertFiles(files...)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := server.reloadSNICertificates()
+	if err != nil {
+		return err
+	}
+
+	return server.loadTLSConfig()
+}
+
+// reloadSNICertificates reloads the SNI certificates loaded from files, and replaces them only if all of them are loaded.
+func (server *server) reloadSNICertificates() error {
+	server.sniMutex.RLock()
+	sniCerts := maps.Clone(server.sniCerts)
+	server.sniMutex.RUnlock()
+
+	newCerts := map[string]*sniCertificate{}
+
+	for name, sniCert := range sniCerts {
+		if len(sniCert.certFile) == 0 {
+			continue
+		}
+
+		cert, err := tls.LoadX509KeyPair(sniCert.certFile, sniCert.keyFile)
+		if err != nil {
+			return err
+		}
+
+		newCerts[name] = &sniCertificate{
+			cert:     &cert,
+			certFile: sniCert.certFile,
+			keyFile:  sniCert.keyFile,
+		}
+	}
+
+	server.sniMutex.Lock()
+	defer server.sniMutex.Unlock()
+
+	for name, newCert := range newCerts {
+		// Skips the certificates which are changed or removed while loading.
+		if server.sniCerts[name] != sniCerts[name] {
+			continue
+		}
+
+		server.sniCerts[name] = newCert
+	}
+
+	return nil
+}
+
+// loadTLSConfig builds a TLS configuration from the current certificates and activates it for new connections.
+// The caller must hold tlsReloadMutex.
+func (server *server) loadTLSConfig() error {
+	baseConfig, err := server.TLSConfig()
+	if err != nil {
+		return err
+	}
+
+	if baseConfig == nil {
+		return nil
+	}
+
+	tlsConfig := baseConfig.Clone()
+	tlsConfig.GetCertificate = server.sniCertificate
+
+	server.activeTLSConfig.Store(tlsConfig)
+
+	return nil
+}
+
+// newServerTLSConfig returns a TLS configuration which uses the active TLS configuration for each handshake.
+func (server *server) newServerTLSConfig() *tls.Config {
+	return &tls.Config{ // nolint: exhaustruct
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return server.activeTLSConfig.Load(), nil
+		},
+	}
+}
+
+// sniCertificate returns the certificate for the SNI server name, or nil to use the default server certificate.
+func (server *server) sniCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if len(hello.ServerName) == 0 {
+		return nil, nil
+	}
+
+	server.sniMutex.RLock()
+	defer server.sniMutex.RUnlock()
+
+	name := strings.ToLower(hello.ServerName)
+	if sniCert, ok := server.sniCerts[name]; ok {
+		return sniCert.cert, nil
+	}
+
+	if _, domain, ok := strings.Cut(name, "."); ok {
+		if sniCert, ok := server.sniCerts["*."+domain]; ok {
+			return sniCert.cert, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// startTLSWatcher starts watching the certificate files to reload them when they are changed.
+func (server *server) startTLSWatcher() {
+	interval := server.TLSReloadInterval()
+	if interval <= 0 {
+		return
+	}
+
+	stopCh := make(chan struct{})
+	server.tlsWatcherStopCh = stopCh
+
+	go server.watchTLSFiles(interval, stopCh)
+}
+
+// stopTLSWatcher stops watching the certificate files.
+func (server *server) stopTLSWatcher() {
+	if server.tlsWatcherStopCh == nil {
+		return
+	}
+
+	close(server.tlsWatcherStopCh)
+	server.tlsWatcherStopCh = nil
+}
+
+// watchTLSFiles reloads the certificates when the modification times of the certificate files are changed.
+func (server *server) watchTLSFiles(interval time.Duration, stopCh chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	modTimes := server.tlsFileModTimes()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		currModTimes := server.tlsFileModTimes()
+		if maps.Equal(modTimes, currModTimes) {
+			continue
+		}
+
+		err := server.ReloadTLS()
+		if err != nil {
+			log.Errorf("%s/%s TLS certificates couldn't be reloaded (%s)", PackageName, Version, err)
+			continue
+		}
+
+		modTimes = currModTimes
+
+		log.Infof("%s/%s TLS certificates reloaded", PackageName, Version)
+	}
+}
+
+// tlsFileModTimes returns the modification times of the certificate files.
+func (server *server) tlsFileModTimes() map[string]time.Time {
+	files := append([]string{server.TLSCertFile(), server.TLSKeyFile()}, server.TLSCACertFiles()...)
+
+	server.sniMutex.RLock()
+	for _, sniCert := range server.sniCerts {
+		files = append(files, sniCert.certFile, sniCert.keyFile)
+	}
+	server.sniMutex.RUnlock()
+
+	modTimes := map[string]time.Time{}
+
+	for _, file := range files {
+		if len(file) == 0 {
+			continue
+		}
+
+		fi, err := os.Stat(file)
+		if err != nil {
+			modTimes[file] = time.Time{}
+			continue
+		}
+
+		modTimes[file] = fi.ModTime()
+	}
+
+	return modTimes
+}
+
+// isTLSFileConfig returns true if the specified configuration parameter is a certificate file.
+func isTLSFileConfig(key string) bool {
+	switch strings.ToLower(key) {
+	case tlsCertFile, tlsKeyFile, tlsCACertFile:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/cybergarage/go-logger/log"
+)
+
+const (
+	// tlsHandshakeRecordType is the first byte of TLS handshake records.
+	tlsHandshakeRecordType = 0x16
+)
+
+// peekedConn represents a connection whose first bytes have been peeked.
+type peekedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// Read reads data including the peeked bytes.
+func (conn *peekedConn) Read(b []byte) (int, error) {
+	return conn.reader.Read(b)
+}
+
+// detectTLSConn peeks the first byte of the specified connection, and returns the connection and true if the client starts a TLS handshake.
+func detectTLSConn(conn net.Conn) (net.Conn, bool, error) {
+	err := conn.SetReadDeadline(time.Now().Add(DefaultTLSDetectTimeout))
+	if err != nil {
+		return nil, false, err
+	}
+
+	reader := bufio.NewReader(conn)
+
+	b, err := reader.Peek(1)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = conn.SetReadDeadline(time.Time{})
+	if err != nil {
+		return nil, false, err
+	}
+
+	peekedConn := &peekedConn{
+		Conn:   conn,
+		reader: reader,
+	}
+
+	return peekedConn, b[0] == tlsHandshakeRecordType, nil
+}
+
+// receiveAutoDetectConn handles a client connection performing a TLS handshake if the client starts it.
+func (server *server) receiveAutoDetectConn(conn net.Conn) error {
+	detectedConn, isTLS, err := detectTLSConn(conn)
+	if err != nil {
+		return errors.Join(err, conn.Close())
+	}
+
+	conn = detectedConn
+
+	if isTLS {
+		tlsConn := tls.Server(conn, server.tlsConfig)
+
+		err := server.handshake(tlsConn)
+		if err != nil {
+			return err
+		}
+
+		return server.receive(tlsConn, tlsConn)
+	}
+
+	if server.IsTLSRequiredForRemote() && !isLoopbackConn(conn) {
+		server.stats.rejectedConns.Add(1)
+		log.Warnf("%s/%s (%s) rejected: %s", PackageName, Version, conn.RemoteAddr().String(), ErrTLSRequired)
+
+		return errors.Join(ErrTLSRequired, server.rejectConn(conn, ErrTLSRequired), conn.Close())
+	}
+
+	return server.receive(conn, nil)
+}
@@ -0,0 +1,446 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testCertificate returns a self-signed certificate and key in PEM for the specified common name.
+func testCertificate(t *testing.T, cn string) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{ // nolint: exhaustruct
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn}, // nolint: exhaustruct
+		DNSNames:     []string{cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Headers: nil, Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Headers: nil, Bytes: keyDer})
+
+	return certPEM, keyPEM
+}
+
+// writeTestCertificate writes a self-signed certificate and key for the specified common name to the files.
+func writeTestCertificate(t *testing.T, cn string, certFile string, keyFile string) {
+	t.Helper()
+
+	cert, key := testCertificate(t, cn)
+
+	if err := os.WriteFile(certFile, cert, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(keyFile, key, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testTLSPeerName connects to the specified address, and returns the common name of the server certificate.
+func testTLSPeerName(t *testing.T, addr string, serverName string) (*tls.Conn, string) {
+	t.Helper()
+
+	conn, err := tls.Dial("tcp", addr, &tls.Config{ // nolint: exhaustruct
+		ServerName:         serverName,
+		InsecureSkipVerify: true, // nolint: gosec
+	})
+	if err != nil {
+		t.Error(err)
+		return nil, ""
+	}
+
+	return conn, conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+}
+
+func TestServerTLSReload(t *testing.T) {
+	const testPort = 6384
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	writeTestCertificate(t, "first.example", certFile, keyFile)
+
+	server := NewServer()
+	server.SetPort(0)
+	server.SetTLSPort(testPort)
+	server.SetClientAuthType(tls.NoClientCert)
+
+	if err := server.SetServerCertFile(certFile); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := server.SetServerKeyFile(keyFile); err != nil {
+		t.Error(err)
+		return
+	}
+
+	err := server.Start()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer server.Stop()
+
+	addr := net.JoinHostPort(LocalHost, strconv.Itoa(testPort))
+
+	conn, cn := testTLSPeerName(t, addr, "")
+	if conn == nil {
+		return
+	}
+
+	defer conn.Close()
+
+	if cn != "first.example" {
+		t.Errorf("%s != %s", cn, "first.example")
+	}
+
+	// Reloads the rotated certificate on demand.
+
+	writeTestCertificate(t, "second.example", certFile, keyFile)
+
+	err = server.ReloadTLS()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	reloadedConn, cn := testTLSPeerName(t, addr, "")
+	if reloadedConn == nil {
+		return
+	}
+
+	reloadedConn.Close()
+
+	if cn != "second.example" {
+		t.Errorf("%s != %s", cn, "second.example")
+	}
+
+	// Established connections are not dropped by reloading.
+
+	testServerPing(t, conn)
+
+	// Selects certificates by SNI server names.
+
+	for _, name := range []string{"tenant.example", "*.wildcard.example"} {
+		cert, key := testCertificate(t, name)
+
+		sniCert, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		server.SetSNICertificate(name, sniCert)
+	}
+
+	sniTests := []struct {
+		serverName string
+		expected   string
+	}{
+		{serverName: "tenant.example", expected: "tenant.example"},
+		{serverName: "a.wildcard.example", expected: "*.wildcard.example"},
+		{serverName: "unknown.example", expected: "second.example"},
+	}
+
+	for _, test := range sniTests {
+		sniConn, cn := testTLSPeerName(t, addr, test.serverName)
+		if sniConn == nil {
+			return
+		}
+
+		sniConn.Close()
+
+		if cn != test.expected {
+			t.Errorf("%s: %s != %s", test.serverName, cn, test.expected)
+		}
+	}
+
+	// Concurrent reloads are serialized.
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Go(func() {
+			if err := server.ReloadTLS(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	// A mismatched pair of the certificate and key is not loaded.
+
+	cert, _ := testCertificate(t, "third.example")
+	if err := os.WriteFile(certFile, cert, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := server.ReloadTLS(); err == nil {
+		t.Error("mismatched certificate and key should not be reloaded")
+	}
+
+	reloadedConn, cn = testTLSPeerName(t, addr, "")
+	if reloadedConn == nil {
+		return
+	}
+
+	reloadedConn.Close()
+
+	if cn != "second.example" {
+		t.Errorf("%s != %s", cn, "second.example")
+	}
+}
+
+func TestServerTLSReloadWatcher(t *testing.T) {
+	const testPort = 6385
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	writeTestCertificate(t, "first.example", certFile, keyFile)
+
+	server := NewServer()
+	server.SetPort(0)
+	server.SetTLSPort(testPort)
+	server.SetClientAuthType(tls.NoClientCert)
+	server.SetTLSReloadInterval(time.Second)
+
+	if err := server.SetServerCertFile(certFile); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := server.SetServerKeyFile(keyFile); err != nil {
+		t.Error(err)
+		return
+	}
+
+	err := server.Start()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer server.Stop()
+
+	// Ensures the modification times of the rewritten files are changed.
+	time.Sleep(10 * time.Millisecond)
+
+	writeTestCertificate(t, "second.example", certFile, keyFile)
+
+	addr := net.JoinHostPort(LocalHost, strconv.Itoa(testPort))
+
+	for range 50 {
+		conn, cn := testTLSPeerName(t, addr, "")
+		if conn == nil {
+			return
+		}
+
+		conn.Close()
+
+		if cn == "second.example" {
+			return
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	t.Errorf("certificates are not reloaded")
+}
+
+func TestServerTLSAutoDetect(t *testing.T) {
+	const testPort = 6386
+
+	cert, key := testCertificate(t, "auto.example")
+
+	server := NewServer()
+	server.SetPort(testPort)
+	server.SetTLSAutoDetect(true)
+	server.SetTLSRequiredForRemote(true)
+	server.SetClientAuthType(tls.NoClientCert)
+	server.SetServerCert(cert)
+	server.SetServerKey(key)
+
+	err := server.Start()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer server.Stop()
+
+	addr := net.JoinHostPort(LocalHost, strconv.Itoa(testPort))
+
+	// Plaintext clients from the loopback interface are accepted.
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer conn.Close()
+
+	testServerPing(t, conn)
+
+	// TLS clients are accepted on the same port.
+
+	tlsConn, cn := testTLSPeerName(t, addr, "")
+	if tlsConn == nil {
+		return
+	}
+
+	defer tlsConn.Close()
+
+	if cn != "auto.example" {
+		t.Errorf("%s != %s", cn, "auto.example")
+	}
+
+	testServerPing(t, tlsConn)
+
+	// Plaintext clients from non-loopback interfaces are refused.
+
+	clientConn, serverConn := net.Pipe()
+	remoteAddr := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 6379, Zone: ""}
+
+	go server.ServeConn(&testRemoteConn{Conn: serverConn, remoteAddr: remoteAddr})
+
+	go clientConn.Write([]byte("*1\r\n$4\r\nPING\r\n"))
+
+	res, err := bufio.NewReader(clientConn).ReadString('\n')
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if expected := "-" + ErrTLSRequired.Error() + "\r\n"; res != expected {
+		t.Errorf("%q != %q", res, expected)
+	}
+
+	clientConn.Close()
+}
+
+func TestServerTLSHandshakeFailure(t *testing.T) {
+	const testPort = 6387
+
+	cert, key := testCertificate(t, "handshake.example")
+
+	server := NewServer()
+	server.SetPort(0)
+	server.SetTLSPort(testPort)
+	server.SetTLSHandshakeTimeout(time.Second)
+	server.SetClientAuthType(tls.NoClientCert)
+	server.SetServerCert(cert)
+	server.SetServerKey(key)
+
+	err := server.Start()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer server.Stop()
+
+	addr := net.JoinHostPort(LocalHost, strconv.Itoa(testPort))
+
+	// A slow client which never starts the handshake does not block other clients.
+
+	slowConn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer slowConn.Close()
+
+	// A plaintext client fails the handshake.
+
+	plainConn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer plainConn.Close()
+
+	_, err = plainConn.Write([]byte("*1\r\n$4\r\nPING\r\n"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// The listener still accepts TLS clients.
+
+	conn, cn := testTLSPeerName(t, addr, "")
+	if conn == nil {
+		return
+	}
+
+	defer conn.Close()
+
+	if cn != "handshake.example" {
+		t.Errorf("%s != %s", cn, "handshake.example")
+	}
+
+	for range 50 {
+		if server.Stats().TLSHandshakeFailures() == 2 {
+			return
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if n := server.Stats().TLSHandshakeFailures(); n != 2 {
+		t.Errorf("%d != %d", n, 2)
+	}
+}
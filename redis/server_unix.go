@@ -0,0 +1,92 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+)
+
+// openUnixSocket listens on the Unix domain socket after removing a stale socket file.
+func (server *server) openUnixSocket() (net.Listener, error) {
+	path := server.UnixSocket()
+
+	err := removeStaleUnixSocket(path)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	perm := server.UnixSocketPerm()
+	if 0 < perm {
+		err := os.Chmod(path, perm)
+		if err != nil {
+			l.Close()
+			return nil, err
+		}
+	}
+
+	return l, nil
+}
+
+// closeUnixSocket closes the specified listener and removes the socket file.
+func (server *server) closeUnixSocket(l net.Listener) error {
+	err := l.Close()
+	if err != nil {
+		return err
+	}
+
+	return removeUnixSocket(server.UnixSocket())
+}
+
+// removeStaleUnixSocket removes the specified socket file if no server is listening on it.
+func removeStaleUnixSocket(path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	if fi.Mode().Type() != fs.ModeSocket {
+		return fmt.Errorf(errorNotUnixSocket, path)
+	}
+
+	conn, err := net.Dial("unix", path)
+	if err == nil {
+		conn.Close()
+		return fmt.Errorf(errorUnixSocketInUse, path)
+	}
+
+	return removeUnixSocket(path)
+}
+
+// removeUnixSocket removes the specified socket file if it exists.
+func removeUnixSocket(path string) error {
+	err := os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,260 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"fmt"
+)
+
+// setAlgebra represents an operation of the set algebra commands.
+type setAlgebra int
+
+const (
+	setInter setAlgebra = iota
+	setUnion
+	setDiff
+)
+
+// nolint: gocyclo, maintidx
+func (server *server) registerSetAlgebraExecutors() {
+	algebraExecutor := func(op setAlgebra) Executor {
+		return func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+			keys, err := nextSetAlgebraKeysArguments(cmd, args)
+			if err != nil {
+				return nil, err
+			}
+
+			members, err := server.setAlgebra(conn, op, keys)
+			if err != nil {
+				return nil, err
+			}
+
+			return NewStringArrayMessage(members), nil
+		}
+	}
+
+	algebraStoreExecutor := func(op setAlgebra) Executor {
+		return func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+			dst, err := nextStringArgument(cmd, "destination", args)
+			if err != nil {
+				return nil, err
+			}
+
+			keys, err := nextSetAlgebraKeysArguments(cmd, args)
+			if err != nil {
+				return nil, err
+			}
+
+			lockedKeys := server.keyLock.LockKeys(conn.Database(), append([]string{dst}, keys...)...)
+			defer server.keyLock.UnlockKeys(conn.Database(), lockedKeys)
+
+			members, err := server.setAlgebra(conn, op, keys)
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = server.userCommandHandler.Del(conn, []string{dst})
+			if err != nil {
+				return nil, err
+			}
+
+			if 0 < len(members) {
+				_, err = server.userCommandHandler.SAdd(conn, dst, members)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			return NewIntegerMessage(len(members)), nil
+		}
+	}
+
+	server.RegisterExexutor("SINTER", algebraExecutor(setInter))
+	server.RegisterExexutor("SUNION", algebraExecutor(setUnion))
+	server.RegisterExexutor("SDIFF", algebraExecutor(setDiff))
+	server.RegisterExexutor("SINTERSTORE", algebraStoreExecutor(setInter))
+	server.RegisterExexutor("SUNIONSTORE", algebraStoreExecutor(setUnion))
+	server.RegisterExexutor("SDIFFSTORE", algebraStoreExecutor(setDiff))
+
+	server.RegisterExexutor("SINTERCARD", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		keys, limit, err := nextSInterCardArguments(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		members, err := server.setAlgebra(conn, setInter, keys)
+		if err != nil {
+			return nil, err
+		}
+
+		card := len(members)
+		if 0 < limit && limit < card {
+			card = limit
+		}
+
+		return NewIntegerMessage(card), nil
+	})
+}
+
+// setAlgebra returns the members of the set algebra of the specified keys by SetAlgebraHandler, or by SMembers of SetCommandHandler.
+func (server *server) setAlgebra(conn *Conn, op setAlgebra, keys []string) ([]string, error) {
+	var res *Message
+
+	var err error
+
+	if handler, ok := server.userCommandHandler.(SetAlgebraHandler); ok {
+		switch op {
+		case setInter:
+			res, err = handler.SInter(conn, keys)
+		case setUnion:
+			res, err = handler.SUnion(conn, keys)
+		case setDiff:
+			res, err = handler.SDiff(conn, keys)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return messageStrings(res)
+	}
+
+	sets := make([][]string, len(keys))
+	for n, key := range keys {
+		res, err = server.userCommandHandler.SMembers(conn, key)
+		if err != nil {
+			return nil, err
+		}
+
+		sets[n], err = messageStrings(res)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch op {
+	case setInter:
+		return interSets(sets), nil
+	case setUnion:
+		return unionSets(sets), nil
+	case setDiff:
+		return diffSets(sets), nil
+	}
+
+	return nil, fmt.Errorf("%w set algebra (%d)", ErrInvalid, op)
+}
+
+// messageStrings returns the strings of the specified array message.
+func messageStrings(msg *Message) ([]string, error) {
+	array, err := msg.Array()
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := array.NextMessages()
+	if err != nil {
+		return nil, err
+	}
+
+	strs := make([]string, len(msgs))
+	for n, msg := range msgs {
+		strs[n], err = msg.String()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return strs, nil
+}
+
+// newMemberSet returns a lookup set of the specified members.
+func newMemberSet(members []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(members))
+	for _, member := range members {
+		set[member] = struct{}{}
+	}
+
+	return set
+}
+
+// interSets returns the members of the first set which are in all the other sets.
+func interSets(sets [][]string) []string {
+	if len(sets) == 0 {
+		return []string{}
+	}
+
+	others := make([]map[string]struct{}, len(sets)-1)
+	for n, set := range sets[1:] {
+		others[n] = newMemberSet(set)
+	}
+
+	members := []string{}
+
+	for _, member := range sets[0] {
+		inAll := true
+
+		for _, other := range others {
+			if _, ok := other[member]; !ok {
+				inAll = false
+				break
+			}
+		}
+
+		if inAll {
+			members = append(members, member)
+		}
+	}
+
+	return members
+}
+
+// unionSets returns the members of all the sets in the order of appearance.
+func unionSets(sets [][]string) []string {
+	members := []string{}
+	found := map[string]struct{}{}
+
+	for _, set := range sets {
+		for _, member := range set {
+			if _, ok := found[member]; ok {
+				continue
+			}
+
+			found[member] = struct{}{}
+			members = append(members, member)
+		}
+	}
+
+	return members
+}
+
+// diffSets returns the members of the first set which are not in any of the other sets.
+func diffSets(sets [][]string) []string {
+	if len(sets) == 0 {
+		return []string{}
+	}
+
+	others := unionSets(sets[1:])
+	otherSet := newMemberSet(others)
+
+	members := []string{}
+
+	for _, member := range sets[0] {
+		if _, ok := otherSet[member]; !ok {
+			members = append(members, member)
+		}
+	}
+
+	return members
+}
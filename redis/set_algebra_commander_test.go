@@ -0,0 +1,105 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestSetAlgebra(t *testing.T) {
+	sets := [][]string{
+		{"a", "b", "c", "d"},
+		{"c"},
+		{"a", "c", "e"},
+	}
+
+	tests := []struct {
+		name     string
+		fn       func([][]string) []string
+		expected []string
+	}{
+		{"SINTER", interSets, []string{"c"}},
+		{"SUNION", unionSets, []string{"a", "b", "c", "d", "e"}},
+		{"SDIFF", diffSets, []string{"b", "d"}},
+	}
+
+	for _, test := range tests {
+		if got := test.fn(sets); !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%s: %v != %v", test.name, got, test.expected)
+		}
+
+		if got := test.fn([][]string{}); len(got) != 0 {
+			t.Errorf("%s: %v != []", test.name, got)
+		}
+	}
+}
+
+func TestSetAlgebraStoreCommands(t *testing.T) {
+	handler := &testSetCommandHandler{
+		testCommandHandler: testCommandHandler{UserCommandHandler: nil},
+		mutex:              &sync.Mutex{},
+		sets: map[string][]string{
+			"set1": {"a", "b", "c"},
+			"set2": {"d", "e", "f"},
+		},
+	}
+
+	server, conn := testSugarServer(t, handler)
+
+	const n = 20
+
+	var wg sync.WaitGroup
+
+	for i := range n {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			src := "set1"
+			if i%2 == 0 {
+				src = "set2"
+			}
+
+			if _, err := server.executeCommand(conn, "SUNIONSTORE", NewStringArguments("dst", src)); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	dst := slices.Clone(handler.sets["dst"])
+	slices.Sort(dst)
+
+	if !slices.Equal(dst, handler.sets["set1"]) && !slices.Equal(dst, handler.sets["set2"]) {
+		t.Errorf("%v is mixed", dst)
+	}
+
+	if n := server.keyLock.Len(); n != 0 {
+		t.Errorf("%d != %d", n, 0)
+	}
+
+	for _, numKeys := range []string{"3", "9223372036854775807"} {
+		_, err := server.executeCommand(conn, "SINTERCARD", NewStringArguments(numKeys, "set1", "set2"))
+		if !errors.Is(err, ErrNumKeysExceedArgs) {
+			t.Errorf("%s: %v != %v", numKeys, err, ErrNumKeysExceedArgs)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"bytes"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// sortElement represents an element of SORT command with the weight to sort by.
+type sortElement struct {
+	value  []byte
+	weight []byte
+	score  float64
+}
+
+// nolint: gocyclo, maintidx, nilerr
+func (server *server) registerSortExecutors() {
+	sortExecutor := func(conn *Conn, cmd string, args Arguments, readOnly bool) (*Message, error) {
+		key, opt, err := nextSortArguments(cmd, readOnly, args)
+		if err != nil {
+			return nil, err
+		}
+
+		// Locks the source and destination keys not to interleave the other commands between reading and storing.
+		if 0 < len(opt.STORE) {
+			lockedKeys := server.keyLock.LockKeys(conn.Database(), opt.STORE, key)
+			defer server.keyLock.UnlockKeys(conn.Database(), lockedKeys)
+		}
+
+		values, err := server.sortValues(conn, key)
+		if err != nil {
+			return nil, err
+		}
+
+		// BY pattern without '*' such as "nosort" skips sorting.
+		doSort := len(opt.BY) == 0 || strings.Contains(opt.BY, "*")
+
+		elems := make([]*sortElement, len(values))
+		for n, value := range values {
+			elems[n] = &sortElement{
+				value:  value,
+				weight: value,
+				score:  0,
+			}
+		}
+
+		if doSort {
+			for _, elem := range elems {
+				if 0 < len(opt.BY) {
+					elem.weight = server.sortLookup(conn, opt.BY, elem.value)
+				}
+
+				if opt.ALPHA || elem.weight == nil {
+					continue
+				}
+
+				elem.score, err = strconv.ParseFloat(string(elem.weight), 64)
+				if err != nil {
+					return nil, ErrSortScore
+				}
+			}
+
+			slices.SortStableFunc(elems, func(a, b *sortElement) int {
+				cmp := compareSortElements(a, b, opt.ALPHA)
+				if opt.DESC {
+					return -cmp
+				}
+
+				return cmp
+			})
+		}
+
+		elems = limitSortElements(elems, opt.Offset, opt.Count)
+
+		results := [][]byte{}
+		for _, elem := range elems {
+			if len(opt.GET) == 0 {
+				results = append(results, elem.value)
+				continue
+			}
+
+			for _, pattern := range opt.GET {
+				results = append(results, server.sortLookup(conn, pattern, elem.value))
+			}
+		}
+
+		if 0 < len(opt.STORE) {
+			return server.sortStore(conn, opt.STORE, results)
+		}
+
+		array := NewArrayMessage()
+		for _, result := range results {
+			msg := NewNilMessage()
+			if result != nil {
+				msg = NewBulkMessageBytes(result)
+			}
+
+			err := array.Append(msg)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return array, nil
+	}
+
+	server.RegisterExexutor("SORT", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		return sortExecutor(conn, cmd, args, false)
+	})
+
+	server.RegisterExexutor("SORT_RO", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		return sortExecutor(conn, cmd, args, true)
+	})
+}
+
+// compareSortElements compares the elements by the weights, and by the values if the weights are equal as Redis does.
+func compareSortElements(a, b *sortElement, alpha bool) int {
+	cmp := 0
+
+	switch {
+	case !alpha:
+		switch {
+		case a.score < b.score:
+			cmp = -1
+		case b.score < a.score:
+			cmp = 1
+		}
+	case a.weight == nil && b.weight == nil:
+		cmp = 0
+	case a.weight == nil:
+		cmp = -1
+	case b.weight == nil:
+		cmp = 1
+	default:
+		cmp = bytes.Compare(a.weight, b.weight)
+	}
+
+	if cmp != 0 {
+		return cmp
+	}
+
+	return bytes.Compare(a.value, b.value)
+}
+
+// limitSortElements returns the elements in the range of LIMIT option.
+func limitSortElements(elems []*sortElement, offset int, count int) []*sortElement {
+	start := max(offset, 0)
+	if len(elems) <= start {
+		return []*sortElement{}
+	}
+
+	end := len(elems)
+	// Compares without adding the count not to overflow.
+	if 0 <= count && count < end-start {
+		end = start + count
+	}
+
+	return elems[start:end]
+}
+
+// sortValues returns the elements of the list, set or sorted set key to sort.
+func (server *server) sortValues(conn *Conn, key string) ([][]byte, error) {
+	res, err := server.userCommandHandler.Type(conn, key)
+	if err != nil {
+		return nil, err
+	}
+
+	keyType, err := res.String()
+	if err != nil {
+		return nil, err
+	}
+
+	switch keyType {
+	case "none":
+		return [][]byte{}, nil
+	case "list":
+		res, err = server.userCommandHandler.LRange(conn, key, 0, -1)
+	case "set":
+		res, err = server.userCommandHandler.SMembers(conn, key)
+	case "zset":
+		opt := ZRangeOption{
+			BYSCORE:      false,
+			BYLEX:        false,
+			REV:          false,
+			WITHSCORES:   false,
+			MINEXCLUSIVE: false,
+			MAXEXCLUSIVE: false,
+			Offset:       0,
+			Count:        -1,
+		}
+		res, err = server.userCommandHandler.ZRange(conn, key, 0, -1, opt)
+	default:
+		return nil, ErrWrongType
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	array, err := res.Array()
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := array.NextMessages()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([][]byte, len(msgs))
+	for n, msg := range msgs {
+		values[n], err = msg.Bytes()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// sortLookup returns the value of the external key of the BY or GET pattern for the specified element.
+// The pattern "#" returns the element itself, and "->field" dereferences the hash field. It returns nil if no value is found.
+func (server *server) sortLookup(conn *Conn, pattern string, value []byte) []byte {
+	if pattern == "#" {
+		return value
+	}
+
+	star := strings.Index(pattern, "*")
+	if star < 0 {
+		return nil
+	}
+
+	keyPattern := pattern
+	field := ""
+
+	if arrow := strings.Index(pattern[star+1:], "->"); 0 <= arrow && star+1+arrow+2 < len(pattern) {
+		keyPattern = pattern[:star+1+arrow]
+		field = pattern[star+1+arrow+2:]
+	}
+
+	key := keyPattern[:star] + string(value) + keyPattern[star+1:]
+
+	var res *Message
+
+	var err error
+
+	if 0 < len(field) {
+		res, err = server.userCommandHandler.HGet(conn, key, field)
+	} else {
+		res, err = server.userCommandHandler.Get(conn, key)
+	}
+
+	// Values of the wrong types are treated as missing values as Redis does.
+	if err != nil || res == nil || res.IsNil() {
+		return nil
+	}
+
+	val, err := res.Bytes()
+	if err != nil {
+		return nil
+	}
+
+	return val
+}
+
+// sortStore replaces the destination key with a list of the results, and returns the length.
+func (server *server) sortStore(conn *Conn, key string, results [][]byte) (*Message, error) {
+	_, err := server.userCommandHandler.Del(conn, []string{key})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return NewIntegerMessage(0), nil
+	}
+
+	elems := make([]string, len(results))
+	for n, result := range results {
+		elems[n] = string(result)
+	}
+
+	_, err = server.userCommandHandler.RPush(conn, key, elems, PushOption{X: false})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewIntegerMessage(len(elems)), nil
+}
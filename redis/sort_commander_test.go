@@ -0,0 +1,133 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testSortCommandHandler is a user command handler which implements the list handlers used by SORT command.
+type testSortCommandHandler struct {
+	testListCommandHandler
+}
+
+func (handler *testSortCommandHandler) Type(conn *Conn, key string) (*Message, error) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	if _, ok := handler.lists[key]; !ok {
+		return NewStringMessage("none"), nil
+	}
+
+	return NewStringMessage("list"), nil
+}
+
+func (handler *testSortCommandHandler) Del(conn *Conn, keys []string) (*Message, error) {
+	handler.mutex.Lock()
+
+	removed := 0
+
+	for _, key := range keys {
+		if _, ok := handler.lists[key]; ok {
+			delete(handler.lists, key)
+			removed++
+		}
+	}
+
+	handler.mutex.Unlock()
+
+	// Widens the window between Del and RPush of SORT STORE.
+	time.Sleep(time.Millisecond)
+
+	return NewIntegerMessage(removed), nil
+}
+
+func TestLimitSortElements(t *testing.T) {
+	elems := make([]*sortElement, 3)
+	for n := range elems {
+		elems[n] = &sortElement{value: []byte{byte('a' + n)}, weight: nil, score: float64(n)}
+	}
+
+	tests := []struct {
+		offset   int
+		count    int
+		expected string
+	}{
+		{0, -1, "abc"},
+		{0, 2, "ab"},
+		{1, 5, "bc"},
+		{1, math.MaxInt, "bc"},
+		{math.MaxInt, math.MaxInt, ""},
+		{-1, 1, "a"},
+		{3, 1, ""},
+	}
+
+	for _, test := range tests {
+		res := ""
+		for _, elem := range limitSortElements(elems, test.offset, test.count) {
+			res += string(elem.value)
+		}
+
+		if res != test.expected {
+			t.Errorf("LIMIT %d %d: %s != %s", test.offset, test.count, res, test.expected)
+		}
+	}
+}
+
+func TestSortStoreCommand(t *testing.T) {
+	handler := &testSortCommandHandler{
+		testListCommandHandler: testListCommandHandler{
+			testCommandHandler: testCommandHandler{UserCommandHandler: nil},
+			mutex:              &sync.Mutex{},
+			lists: map[string][]string{
+				"list1": {"3", "1", "2"},
+				"list2": {"6", "4", "5"},
+			},
+		},
+	}
+
+	server, conn := testSugarServer(t, handler)
+
+	const n = 20
+
+	var wg sync.WaitGroup
+
+	for i := range n {
+		wg.Go(func() {
+			src := "list1"
+			if i%2 == 0 {
+				src = "list2"
+			}
+
+			if _, err := server.executeCommand(conn, "SORT", NewStringArguments(src, "STORE", "dst")); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if dst := handler.lists["dst"]; !slices.Equal(dst, []string{"1", "2", "3"}) && !slices.Equal(dst, []string{"4", "5", "6"}) {
+		t.Errorf("%v is mixed", dst)
+	}
+
+	if n := server.keyLock.Len(); n != 0 {
+		t.Errorf("%d != %d", n, 0)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"sync/atomic"
+)
+
+// Stats represents server statistics.
+type Stats struct {
+	totalConns       atomic.Int64
+	rejectedConns    atomic.Int64
+	idleTimeoutConns atomic.Int64
+	queryLimitConns  atomic.Int64
+	outputLimitConns atomic.Int64
+	tlsFailures      atomic.Int64
+}
+
+// newStats returns a new server statistics.
+func newStats() *Stats {
+	return &Stats{
+		totalConns:       atomic.Int64{},
+		rejectedConns:    atomic.Int64{},
+		idleTimeoutConns: atomic.Int64{},
+		queryLimitConns:  atomic.Int64{},
+		outputLimitConns: atomic.Int64{},
+		tlsFailures:      atomic.Int64{},
+	}
+}
+
+// TotalConnections returns the total number of accepted connections.
+func (stats *Stats) TotalConnections() int64 {
+	return stats.totalConns.Load()
+}
+
+// RejectedConnections returns the number of connections rejected by the admission control.
+func (stats *Stats) RejectedConnections() int64 {
+	return stats.rejectedConns.Load()
+}
+
+// IdleTimeoutConnections returns the number of connections closed by the idle timeout.
+func (stats *Stats) IdleTimeoutConnections() int64 {
+	return stats.idleTimeoutConns.Load()
+}
+
+// QueryBufferLimitConnections returns the number of connections closed by the query buffer limits.
+func (stats *Stats) QueryBufferLimitConnections() int64 {
+	return stats.queryLimitConns.Load()
+}
+
+// OutputBufferLimitConnections returns the number of connections closed by the output buffer limits.
+func (stats *Stats) OutputBufferLimitConnections() int64 {
+	return stats.outputLimitConns.Load()
+}
+
+// TLSHandshakeFailures returns the number of connections closed by failed or timed out TLS handshakes and rejected client certificates.
+func (stats *Stats) TLSHandshakeFailures() int64 {
+	return stats.tlsFailures.Load()
+}
@@ -15,13 +15,26 @@
 package redis
 
 import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
 	"strconv"
+
+	"github.com/cybergarage/go-redis/redis/proto"
 )
 
 // nolint: gocyclo, maintidx, nilerr
 func (server *server) registerSugarExecutors() {
 	// common internal sugar functions
 	incdecExecutor := func(conn *Conn, cmd string, key string, val int) (*Message, error) {
+		if handler, ok := server.userCommandHandler.(IncrByHandler); ok {
+			return handler.IncrBy(conn, key, val)
+		}
+
+		server.keyLock.Lock(conn.Database(), key)
+		defer server.keyLock.Unlock(conn.Database(), key)
+
 		getRet, err := server.userCommandHandler.Get(conn, key)
 		if err != nil {
 			return nil, err
@@ -38,7 +51,11 @@ func (server *server) registerSugarExecutors() {
 			currVal = retVal
 		}
 
-		newVal := currVal + val
+		newVal, err := addIntegers(currVal, val)
+		if err != nil {
+			return nil, err
+		}
+
 		opt := newDefaultSetOption()
 
 		_, err = server.userCommandHandler.Set(conn, key, strconv.Itoa(newVal), opt)
@@ -49,6 +66,33 @@ func (server *server) registerSugarExecutors() {
 		return NewIntegerMessage(newVal), nil
 	}
 
+	lmoveExecutor := func(conn *Conn, src string, dst string, from ListDirection, to ListDirection) (*Message, error) {
+		lockedKeys := server.keyLock.LockKeys(conn.Database(), src, dst)
+		defer server.keyLock.UnlockKeys(conn.Database(), lockedKeys)
+
+		if handler, ok := server.userCommandHandler.(LMoveHandler); ok {
+			return handler.LMove(conn, src, dst, from, to)
+		}
+
+		elems, err := server.popListElements(conn, src, from, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(elems) == 0 {
+			return NewNilMessage(), nil
+		}
+
+		err = server.pushListElements(conn, dst, to, elems)
+		if err != nil {
+			// Restores the popped element not to lose it if the destination is not a list.
+			_ = server.pushListElements(conn, src, from, elems)
+			return nil, err
+		}
+
+		return NewBulkMessage(elems[0]), nil
+	}
+
 	// Registers sugar string commands.
 
 	server.RegisterExexutor("APPEND", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
@@ -57,6 +101,13 @@ func (server *server) registerSugarExecutors() {
 			return nil, err
 		}
 
+		if handler, ok := server.userCommandHandler.(AppendHandler); ok {
+			return handler.Append(conn, key, appendVal)
+		}
+
+		server.keyLock.Lock(conn.Database(), key)
+		defer server.keyLock.Unlock(conn.Database(), key)
+
 		getRet, err := server.userCommandHandler.Get(conn, key)
 		if err != nil {
 			return nil, err
@@ -97,6 +148,11 @@ func (server *server) registerSugarExecutors() {
 			return nil, err
 		}
 
+		// The minimum integer cannot be negated.
+		if inc == math.MinInt {
+			return nil, ErrIncrOverflow
+		}
+
 		return incdecExecutor(conn, cmd, key, -inc)
 	})
 
@@ -170,8 +226,69 @@ func (server *server) registerSugarExecutors() {
 		return incdecExecutor(conn, cmd, key, inc)
 	})
 
+	server.RegisterExexutor("SETRANGE", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextKeyArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		offset, err := nextIntegerArgument(cmd, "offset", args)
+		if err != nil {
+			return nil, err
+		}
+
+		if offset < 0 {
+			return nil, newInvalidArgumentError(cmd, "offset", ErrOffsetOutOfRange)
+		}
+
+		val, err := nextStringArgument(cmd, "value", args)
+		if err != nil {
+			return nil, err
+		}
+
+		// Compares without adding the offset not to overflow.
+		if MaxStringSize-len(val) < offset {
+			return nil, ErrStringExceedsMaxSize
+		}
+
+		server.keyLock.Lock(conn.Database(), key)
+		defer server.keyLock.Unlock(conn.Database(), key)
+
+		getRet, err := server.userCommandHandler.Get(conn, key)
+		if err != nil {
+			return nil, err
+		}
+
+		currVal := ""
+		if !getRet.IsNil() {
+			currVal, err = getRet.String()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Does not create the key with an empty value.
+		if len(val) == 0 {
+			return NewIntegerMessage(len(currVal)), nil
+		}
+
+		newVal := []byte(currVal)
+		if len(newVal) < offset+len(val) {
+			newVal = append(newVal, make([]byte, offset+len(val)-len(newVal))...)
+		}
+
+		copy(newVal[offset:], val)
+
+		_, err = server.userCommandHandler.Set(conn, key, string(newVal), newDefaultSetOption())
+		if err != nil {
+			return nil, err
+		}
+
+		return NewIntegerMessage(len(newVal)), nil
+	})
+
 	server.RegisterExexutor("STRLEN", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
-		getRet, err := server.executeCommand(conn, "GET", args)
+		getRet, err := server.executeCommand(conn, "GET", args.Rest())
 		if err != nil {
 			return NewIntegerMessage(0), nil
 		}
@@ -185,13 +302,13 @@ func (server *server) registerSugarExecutors() {
 	})
 
 	server.RegisterExexutor("SUBSTR", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
-		return server.executeCommand(conn, "GETRANGE", args)
+		return server.executeCommand(conn, "GETRANGE", args.Rest())
 	})
 
 	// Registers sugar hash commands.
 
 	server.RegisterExexutor("HEXISTS", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
-		getRet, err := server.executeCommand(conn, "HGET", args)
+		getRet, err := server.executeCommand(conn, "HGET", args.Rest())
 		if err != nil {
 			return NewIntegerMessage(0), nil
 		}
@@ -204,8 +321,58 @@ func (server *server) registerSugarExecutors() {
 		return NewIntegerMessage(1), nil
 	})
 
+	server.RegisterExexutor("HINCRBY", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextHashArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		field, err := nextStringArgument(cmd, "field", args)
+		if err != nil {
+			return nil, err
+		}
+
+		inc, err := nextIntegerArgument(cmd, "increment", args)
+		if err != nil {
+			return nil, err
+		}
+
+		if handler, ok := server.userCommandHandler.(HIncrByHandler); ok {
+			return handler.HIncrBy(conn, key, field, inc)
+		}
+
+		server.keyLock.Lock(conn.Database(), key)
+		defer server.keyLock.Unlock(conn.Database(), key)
+
+		getRet, err := server.userCommandHandler.HGet(conn, key, field)
+		if err != nil {
+			return nil, err
+		}
+
+		currVal := 0
+
+		if !getRet.IsNil() {
+			currVal, err = getRet.Integer()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		newVal, err := addIntegers(currVal, inc)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = server.userCommandHandler.HSet(conn, key, field, strconv.Itoa(newVal), HSetOption{NX: false})
+		if err != nil {
+			return nil, err
+		}
+
+		return NewIntegerMessage(newVal), nil
+	})
+
 	server.RegisterExexutor("HKEYS", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
-		getAllRet, err := server.executeCommand(conn, "HGETALL", args)
+		getAllRet, err := server.executeCommand(conn, "HGETALL", args.Rest())
 		if err != nil {
 			return nil, err
 		}
@@ -246,7 +413,7 @@ func (server *server) registerSugarExecutors() {
 	})
 
 	server.RegisterExexutor("HLEN", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
-		retMsg, err := server.executeCommand(conn, "HKEYS", args)
+		retMsg, err := server.executeCommand(conn, "HKEYS", args.Rest())
 		if err != nil {
 			return nil, err
 		}
@@ -260,7 +427,7 @@ func (server *server) registerSugarExecutors() {
 	})
 
 	server.RegisterExexutor("HSTRLEN", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
-		retMsg, err := server.executeCommand(conn, "HGET", args)
+		retMsg, err := server.executeCommand(conn, "HGET", args.Rest())
 		if err != nil {
 			return nil, err
 		}
@@ -278,7 +445,7 @@ func (server *server) registerSugarExecutors() {
 	})
 
 	server.RegisterExexutor("HVALS", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
-		getAllRet, err := server.executeCommand(conn, "HGETALL", args)
+		getAllRet, err := server.executeCommand(conn, "HGETALL", args.Rest())
 		if err != nil {
 			return nil, err
 		}
@@ -385,6 +552,327 @@ func (server *server) registerSugarExecutors() {
 		return NewIntegerMessage(0), nil
 	})
 
+	server.RegisterExexutor("SMISMEMBER", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextKeyArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		members, err := nextStringArrayArguments(cmd, "member", args)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(members) == 0 {
+			return nil, newMissingArgumentError(cmd, "member", proto.ErrEOM)
+		}
+
+		retMsg, err := server.userCommandHandler.SMembers(conn, key)
+		if err != nil {
+			return nil, err
+		}
+
+		setMembers, err := messageStrings(retMsg)
+		if err != nil {
+			return nil, err
+		}
+
+		array := NewArrayMessage()
+
+		for _, member := range members {
+			isMember := 0
+			if slices.Contains(setMembers, member) {
+				isMember = 1
+			}
+
+			err := array.Append(NewIntegerMessage(isMember))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return array, nil
+	})
+
+	server.RegisterExexutor("SPOP", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextKeyArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		count, hasCount, err := nextOptionalCountArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		if count < 0 {
+			return nil, newInvalidArgumentError(cmd, "count", fmt.Errorf(errorShouldBeGreaterThanInt, "count", -1))
+		}
+
+		if !hasCount {
+			count = 1
+		}
+
+		var members []string
+
+		if handler, ok := server.userCommandHandler.(SPopHandler); ok {
+			retMsg, err := handler.SPop(conn, key, count)
+			if err != nil {
+				return nil, err
+			}
+
+			members, err = messageStrings(retMsg)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			server.keyLock.Lock(conn.Database(), key)
+			defer server.keyLock.Unlock(conn.Database(), key)
+
+			retMsg, err := server.userCommandHandler.SMembers(conn, key)
+			if err != nil {
+				return nil, err
+			}
+
+			members, err = messageStrings(retMsg)
+			if err != nil {
+				return nil, err
+			}
+
+			members = randomMembers(members, count)
+
+			if 0 < len(members) {
+				_, err = server.userCommandHandler.SRem(conn, key, members)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if !hasCount {
+			if len(members) == 0 {
+				return NewNilMessage(), nil
+			}
+
+			return NewBulkMessage(members[0]), nil
+		}
+
+		return NewStringArrayMessage(members), nil
+	})
+
+	server.RegisterExexutor("SRANDMEMBER", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, err := nextKeyArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		count, hasCount, err := nextOptionalCountArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		if !hasCount {
+			count = 1
+		}
+
+		// Rejects the negative count before allocating the duplicated members.
+		if count < -MaxRandomMemberCount {
+			return nil, ErrValueOutOfRange
+		}
+
+		handler, hasHandler := server.userCommandHandler.(SRandMemberHandler)
+
+		var retMsg *Message
+
+		if hasHandler {
+			retMsg, err = handler.SRandMember(conn, key, count)
+		} else {
+			retMsg, err = server.userCommandHandler.SMembers(conn, key)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		members, err := messageStrings(retMsg)
+		if err != nil {
+			return nil, err
+		}
+
+		if !hasHandler {
+			members = randomMembers(members, count)
+		}
+
+		if !hasCount {
+			if len(members) == 0 {
+				return NewNilMessage(), nil
+			}
+
+			return NewBulkMessage(members[0]), nil
+		}
+
+		return NewStringArrayMessage(members), nil
+	})
+
+	server.RegisterExexutor("SMOVE", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		src, err := nextKeyArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		dst, err := nextStringArgument(cmd, "destination", args)
+		if err != nil {
+			return nil, err
+		}
+
+		member, err := nextStringArgument(cmd, "member", args)
+		if err != nil {
+			return nil, err
+		}
+
+		lockedKeys := server.keyLock.LockKeys(conn.Database(), src, dst)
+		defer server.keyLock.UnlockKeys(conn.Database(), lockedKeys)
+
+		if handler, ok := server.userCommandHandler.(SMoveHandler); ok {
+			return handler.SMove(conn, src, dst, member)
+		}
+
+		retMsg, err := server.userCommandHandler.SMembers(conn, src)
+		if err != nil {
+			return nil, err
+		}
+
+		members, err := messageStrings(retMsg)
+		if err != nil {
+			return nil, err
+		}
+
+		if !slices.Contains(members, member) {
+			return NewIntegerMessage(0), nil
+		}
+
+		if src == dst {
+			return NewIntegerMessage(1), nil
+		}
+
+		_, err = server.userCommandHandler.SRem(conn, src, []string{member})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = server.userCommandHandler.SAdd(conn, dst, []string{member})
+		if err != nil {
+			// Restores the removed member not to lose it if the destination is not a set.
+			_, _ = server.userCommandHandler.SAdd(conn, src, []string{member})
+			return nil, err
+		}
+
+		return NewIntegerMessage(1), nil
+	})
+
+	// Registers sugar list commands.
+
+	server.RegisterExexutor("LMOVE", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		src, err := nextKeyArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		dst, err := nextStringArgument(cmd, "destination", args)
+		if err != nil {
+			return nil, err
+		}
+
+		from, err := nextListDirectionArgument(cmd, "wherefrom", args)
+		if err != nil {
+			return nil, err
+		}
+
+		to, err := nextListDirectionArgument(cmd, "whereto", args)
+		if err != nil {
+			return nil, err
+		}
+
+		return lmoveExecutor(conn, src, dst, from, to)
+	})
+
+	server.RegisterExexutor("LMPOP", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		keys, dir, count, err := nextLMPopArguments(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		lockedKeys := server.keyLock.LockKeys(conn.Database(), keys...)
+		defer server.keyLock.UnlockKeys(conn.Database(), lockedKeys)
+
+		for _, key := range keys {
+			elems, err := server.popListElements(conn, key, dir, count)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(elems) == 0 {
+				continue
+			}
+
+			array := proto.NewArray()
+			array.Append(NewBulkMessage(key))
+			array.Append(NewStringArrayMessage(elems))
+
+			return NewArrayMessageWithArray(array), nil
+		}
+
+		return NewNilMessage(), nil
+	})
+
+	server.RegisterExexutor("LPOS", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		key, elem, opt, err := nextLPosArguments(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		retMsg, err := server.userCommandHandler.LRange(conn, key, 0, -1)
+		if err != nil {
+			return nil, err
+		}
+
+		elems, err := messageStrings(retMsg)
+		if err != nil {
+			return nil, err
+		}
+
+		idxs := listPositions(elems, elem, opt)
+
+		if opt.Count < 0 {
+			if len(idxs) == 0 {
+				return NewNilMessage(), nil
+			}
+
+			return NewIntegerMessage(idxs[0]), nil
+		}
+
+		array := proto.NewArray()
+		for _, idx := range idxs {
+			array.Append(NewIntegerMessage(idx))
+		}
+
+		return NewArrayMessageWithArray(array), nil
+	})
+
+	server.RegisterExexutor("RPOPLPUSH", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
+		src, err := nextKeyArgument(cmd, args)
+		if err != nil {
+			return nil, err
+		}
+
+		dst, err := nextStringArgument(cmd, "destination", args)
+		if err != nil {
+			return nil, err
+		}
+
+		return lmoveExecutor(conn, src, dst, ListRight, ListLeft)
+	})
+
 	// Registers sugar set commands.
 
 	server.RegisterExexutor("ZCARD", func(conn *Conn, cmd string, args Arguments) (*Message, error) {
@@ -425,3 +913,127 @@ func (server *server) registerSugarExecutors() {
 		return NewIntegerMessage(memberCount), nil
 	})
 }
+
+// addIntegers returns the sum of the specified integers, or ErrIncrOverflow if the sum overflows.
+func addIntegers(val int, inc int) (int, error) {
+	if (0 < inc && math.MaxInt-inc < val) || (inc < 0 && val < math.MinInt-inc) {
+		return 0, ErrIncrOverflow
+	}
+
+	return val + inc, nil
+}
+
+// randomMembers returns up to the count distinct random members, or the absolute count random members which may be duplicated if the count is negative.
+//
+//nolint:gosec
+func randomMembers(members []string, count int) []string {
+	if len(members) == 0 {
+		return []string{}
+	}
+
+	if count < 0 {
+		picked := []string{}
+		for ; count < 0; count++ {
+			picked = append(picked, members[rand.IntN(len(members))])
+		}
+
+		return picked
+	}
+
+	picked := slices.Clone(members)
+	rand.Shuffle(len(picked), func(i, j int) {
+		picked[i], picked[j] = picked[j], picked[i]
+	})
+
+	return picked[:min(count, len(picked))]
+}
+
+// popListElements pops up to the count elements from the specified side of the list.
+func (server *server) popListElements(conn *Conn, key string, dir ListDirection, count int) ([]string, error) {
+	var (
+		retMsg *Message
+		err    error
+	)
+
+	switch dir {
+	case ListLeft:
+		retMsg, err = server.userCommandHandler.LPop(conn, key, count)
+	case ListRight:
+		retMsg, err = server.userCommandHandler.RPop(conn, key, count)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case retMsg == nil, retMsg.IsNil():
+		return []string{}, nil
+	case retMsg.IsArray():
+		return messageStrings(retMsg)
+	}
+
+	elem, err := retMsg.String()
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{elem}, nil
+}
+
+// pushListElements pushes the elements to the specified side of the list.
+func (server *server) pushListElements(conn *Conn, key string, dir ListDirection, elems []string) error {
+	opt := PushOption{X: false}
+
+	var err error
+
+	switch dir {
+	case ListLeft:
+		_, err = server.userCommandHandler.LPush(conn, key, elems, opt)
+	case ListRight:
+		_, err = server.userCommandHandler.RPush(conn, key, elems, opt)
+	}
+
+	return err
+}
+
+// listPositions returns the indexes of the matching elements according to the RANK, COUNT and MAXLEN options of LPOS command.
+func listPositions(elems []string, elem string, opt LPosOption) []int {
+	idxs := []int{}
+
+	skips := opt.Rank - 1
+	step := 1
+	start := 0
+
+	if opt.Rank < 0 {
+		skips = -opt.Rank - 1
+		step = -1
+		start = len(elems) - 1
+	}
+
+	compared := 0
+	for n := start; 0 <= n && n < len(elems); n += step {
+		if 0 < opt.MaxLen && opt.MaxLen <= compared {
+			break
+		}
+
+		compared++
+
+		if elems[n] != elem {
+			continue
+		}
+
+		if 0 < skips {
+			skips--
+			continue
+		}
+
+		idxs = append(idxs, n)
+
+		if opt.Count < 0 || (0 < opt.Count && opt.Count <= len(idxs)) {
+			break
+		}
+	}
+
+	return idxs
+}
@@ -0,0 +1,597 @@
+// Copyright (C) 2022 The go-redis Authors All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"errors"
+	"math"
+	"net"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testStringCommandHandler is a user command handler which implements only the string handlers.
+type testStringCommandHandler struct {
+	testCommandHandler
+	mutex  *sync.Mutex
+	values map[string]string
+}
+
+func (handler *testStringCommandHandler) Set(conn *Conn, key string, val string, opt SetOption) (*Message, error) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	handler.values[key] = val
+
+	return NewOKMessage(), nil
+}
+
+func (handler *testStringCommandHandler) Get(conn *Conn, key string) (*Message, error) {
+	handler.mutex.Lock()
+	val, ok := handler.values[key]
+	handler.mutex.Unlock()
+
+	// Widens the window between Get and Set of the sugar commands.
+	time.Sleep(time.Millisecond)
+
+	if !ok {
+		return NewNilMessage(), nil
+	}
+
+	return NewBulkMessage(val), nil
+}
+
+// testIncrByCommandHandler is a user command handler which implements the optional IncrByHandler.
+type testIncrByCommandHandler struct {
+	testStringCommandHandler
+	calls int
+}
+
+func (handler *testIncrByCommandHandler) IncrBy(conn *Conn, key string, inc int) (*Message, error) {
+	handler.calls++
+	return NewIntegerMessage(inc), nil
+}
+
+// testSetCommandHandler is a user command handler which implements only the set handlers.
+type testSetCommandHandler struct {
+	testCommandHandler
+	mutex *sync.Mutex
+	sets  map[string][]string
+}
+
+func (handler *testSetCommandHandler) SAdd(conn *Conn, key string, members []string) (*Message, error) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	added := 0
+
+	for _, member := range members {
+		if !slices.Contains(handler.sets[key], member) {
+			handler.sets[key] = append(handler.sets[key], member)
+			added++
+		}
+	}
+
+	return NewIntegerMessage(added), nil
+}
+
+func (handler *testSetCommandHandler) Del(conn *Conn, keys []string) (*Message, error) {
+	handler.mutex.Lock()
+
+	removed := 0
+
+	for _, key := range keys {
+		if _, ok := handler.sets[key]; ok {
+			delete(handler.sets, key)
+			removed++
+		}
+	}
+
+	handler.mutex.Unlock()
+
+	// Widens the window between Del and SAdd of the store commands.
+	time.Sleep(time.Millisecond)
+
+	return NewIntegerMessage(removed), nil
+}
+
+func (handler *testSetCommandHandler) SMembers(conn *Conn, key string) (*Message, error) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	return NewStringArrayMessage(handler.sets[key]), nil
+}
+
+func (handler *testSetCommandHandler) SRem(conn *Conn, key string, members []string) (*Message, error) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	removed := 0
+
+	for _, member := range members {
+		if n := slices.Index(handler.sets[key], member); 0 <= n {
+			handler.sets[key] = slices.Delete(handler.sets[key], n, n+1)
+			removed++
+		}
+	}
+
+	return NewIntegerMessage(removed), nil
+}
+
+// testListCommandHandler is a user command handler which implements only the list push, pop and range handlers.
+type testListCommandHandler struct {
+	testCommandHandler
+	mutex *sync.Mutex
+	lists map[string][]string
+}
+
+func (handler *testListCommandHandler) pop(key string, count int, left bool) (*Message, error) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	list := handler.lists[key]
+	if len(list) == 0 {
+		return NewNilMessage(), nil
+	}
+
+	count = min(count, len(list))
+
+	var elems []string
+	if left {
+		elems = list[:count]
+		handler.lists[key] = list[count:]
+	} else {
+		elems = slices.Clone(list[len(list)-count:])
+		slices.Reverse(elems)
+		handler.lists[key] = list[:len(list)-count]
+	}
+
+	if count == 1 {
+		return NewBulkMessage(elems[0]), nil
+	}
+
+	return NewStringArrayMessage(elems), nil
+}
+
+func (handler *testListCommandHandler) LPop(conn *Conn, key string, count int) (*Message, error) {
+	return handler.pop(key, count, true)
+}
+
+func (handler *testListCommandHandler) RPop(conn *Conn, key string, count int) (*Message, error) {
+	return handler.pop(key, count, false)
+}
+
+func (handler *testListCommandHandler) LPush(conn *Conn, key string, elems []string, opt PushOption) (*Message, error) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	for _, elem := range elems {
+		handler.lists[key] = append([]string{elem}, handler.lists[key]...)
+	}
+
+	return NewIntegerMessage(len(handler.lists[key])), nil
+}
+
+func (handler *testListCommandHandler) RPush(conn *Conn, key string, elems []string, opt PushOption) (*Message, error) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	handler.lists[key] = append(handler.lists[key], elems...)
+
+	return NewIntegerMessage(len(handler.lists[key])), nil
+}
+
+func (handler *testListCommandHandler) LRange(conn *Conn, key string, start int, stop int) (*Message, error) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	return NewStringArrayMessage(handler.lists[key]), nil
+}
+
+func testSugarServer(t *testing.T, handler UserCommandHandler) (*server, *Conn) {
+	t.Helper()
+
+	server, ok := NewServer().(*server)
+	if !ok {
+		t.Fatal("invalid server")
+	}
+
+	server.SetCommandHandler(handler)
+
+	clientConn, serverConn := net.Pipe()
+	t.Cleanup(func() { clientConn.Close() })
+
+	conn := newConnWith(serverConn, nil)
+	t.Cleanup(func() { conn.Close() })
+
+	conn.SetAuthrized(true)
+
+	return server, conn
+}
+
+func TestSugarCommandAtomicity(t *testing.T) {
+	handler := &testStringCommandHandler{
+		testCommandHandler: testCommandHandler{UserCommandHandler: nil},
+		mutex:              &sync.Mutex{},
+		values:             map[string]string{},
+	}
+
+	server, conn := testSugarServer(t, handler)
+
+	const n = 20
+
+	var wg sync.WaitGroup
+
+	for range n {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := server.executeCommand(conn, "INCR", NewStringArguments("counter")); err != nil {
+				t.Error(err)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := server.executeCommand(conn, "APPEND", NewStringArguments("log", "x")); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if val := handler.values["counter"]; val != "20" {
+		t.Errorf("%s != %s", val, "20")
+	}
+
+	if n := len(handler.values["log"]); n != 20 {
+		t.Errorf("%d != %d", n, 20)
+	}
+
+	if n := server.keyLock.Len(); n != 0 {
+		t.Errorf("%d != %d", n, 0)
+	}
+
+	res, err := server.executeCommand(conn, "SETRANGE", NewStringArguments("range", "3", "abc"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if n, _ := res.Integer(); n != 6 || handler.values["range"] != "\x00\x00\x00abc" {
+		t.Errorf("%d %q", n, handler.values["range"])
+	}
+
+	for _, offset := range []string{"9223372036854775807", "4000000000", strconv.Itoa(MaxStringSize)} {
+		_, err := server.executeCommand(conn, "SETRANGE", NewStringArguments("range", offset, "x"))
+		if !errors.Is(err, ErrStringExceedsMaxSize) {
+			t.Errorf("%s: %v != %v", offset, err, ErrStringExceedsMaxSize)
+		}
+	}
+
+	if val := handler.values["range"]; val != "\x00\x00\x00abc" {
+		t.Errorf("%q", val)
+	}
+
+	handler.values["max"] = strconv.Itoa(math.MaxInt)
+	handler.values["min"] = strconv.Itoa(math.MinInt)
+
+	overflowTests := []struct {
+		cmd  string
+		args []string
+	}{
+		{"INCR", []string{"max"}},
+		{"INCRBY", []string{"min", "-1"}},
+		{"DECR", []string{"min"}},
+		{"DECRBY", []string{"counter", "-9223372036854775808"}},
+	}
+
+	for _, test := range overflowTests {
+		if _, err := server.executeCommand(conn, test.cmd, NewStringArguments(test.args...)); !errors.Is(err, ErrIncrOverflow) {
+			t.Errorf("%s %v: %v != %v", test.cmd, test.args, err, ErrIncrOverflow)
+		}
+	}
+
+	if val := handler.values["max"]; val != strconv.Itoa(math.MaxInt) {
+		t.Errorf("%s != %d", val, math.MaxInt)
+	}
+}
+
+// testHashCommandHandler is a user command handler which implements only the hash handlers.
+type testHashCommandHandler struct {
+	testCommandHandler
+	mutex  *sync.Mutex
+	fields map[string]string
+}
+
+func (handler *testHashCommandHandler) HSet(conn *Conn, key string, field string, val string, opt HSetOption) (*Message, error) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	handler.fields[key+"/"+field] = val
+
+	return NewIntegerMessage(1), nil
+}
+
+func (handler *testHashCommandHandler) HGet(conn *Conn, key string, field string) (*Message, error) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+
+	val, ok := handler.fields[key+"/"+field]
+	if !ok {
+		return NewNilMessage(), nil
+	}
+
+	return NewBulkMessage(val), nil
+}
+
+func TestSugarHashCommands(t *testing.T) {
+	handler := &testHashCommandHandler{
+		testCommandHandler: testCommandHandler{UserCommandHandler: nil},
+		mutex:              &sync.Mutex{},
+		fields:             map[string]string{"hash/max": strconv.Itoa(math.MaxInt)},
+	}
+
+	server, conn := testSugarServer(t, handler)
+
+	res, err := server.executeCommand(conn, "HINCRBY", NewStringArguments("hash", "field", "5"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if n, _ := res.Integer(); n != 5 {
+		t.Errorf("%d != %d", n, 5)
+	}
+
+	_, err = server.executeCommand(conn, "HINCRBY", NewStringArguments("hash", "max", "1"))
+	if !errors.Is(err, ErrIncrOverflow) {
+		t.Errorf("%v != %v", err, ErrIncrOverflow)
+	}
+
+	if val := handler.fields["hash/max"]; val != strconv.Itoa(math.MaxInt) {
+		t.Errorf("%s != %d", val, math.MaxInt)
+	}
+
+	if n := server.keyLock.Len(); n != 0 {
+		t.Errorf("%d != %d", n, 0)
+	}
+}
+
+func TestSugarCommandIncrByHandler(t *testing.T) {
+	handler := &testIncrByCommandHandler{
+		testStringCommandHandler: testStringCommandHandler{
+			testCommandHandler: testCommandHandler{UserCommandHandler: nil},
+			mutex:              &sync.Mutex{},
+			values:             map[string]string{},
+		},
+		calls: 0,
+	}
+
+	server, conn := testSugarServer(t, handler)
+
+	res, err := server.executeCommand(conn, "DECRBY", NewStringArguments("counter", "5"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if n, _ := res.Integer(); n != -5 {
+		t.Errorf("%d != %d", n, -5)
+	}
+
+	if handler.calls != 1 || len(handler.values) != 0 {
+		t.Errorf("IncrBy has not been called (%d)", handler.calls)
+	}
+}
+
+func TestSugarSetCommands(t *testing.T) {
+	handler := &testSetCommandHandler{
+		testCommandHandler: testCommandHandler{UserCommandHandler: nil},
+		mutex:              &sync.Mutex{},
+		sets:               map[string][]string{"set": {"a", "b", "c"}},
+	}
+
+	server, conn := testSugarServer(t, handler)
+
+	testArrayLen := func(cmd string, args []string, expected int) []string {
+		t.Helper()
+
+		res, err := server.executeCommand(conn, cmd, NewStringArguments(args...))
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+
+		members, err := messageStrings(res)
+		if err != nil {
+			t.Error(err)
+			return nil
+		}
+
+		if len(members) != expected {
+			t.Errorf("%s %v: %v", cmd, args, members)
+		}
+
+		return members
+	}
+
+	// Negative counts allow duplicated members.
+	testArrayLen("SRANDMEMBER", []string{"set", "5"}, 3)
+	testArrayLen("SRANDMEMBER", []string{"set", "-5"}, 5)
+	testArrayLen("SRANDMEMBER", []string{"set", "0"}, 0)
+
+	// Huge negative counts are rejected before allocating the members.
+	for _, count := range []string{"-9223372036854775808", strconv.Itoa(-MaxRandomMemberCount - 1)} {
+		if _, err := server.executeCommand(conn, "SRANDMEMBER", NewStringArguments("set", count)); !errors.Is(err, ErrValueOutOfRange) {
+			t.Errorf("SRANDMEMBER %s: %v", count, err)
+		}
+	}
+
+	res, err := server.executeCommand(conn, "SMISMEMBER", NewStringArguments("set", "a", "x", "c"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	array, err := res.Array()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, expected := range []int{1, 0, 1} {
+		if n, _ := array.NextInteger(); n != expected {
+			t.Errorf("%d != %d", n, expected)
+		}
+	}
+
+	popped := testArrayLen("SPOP", []string{"set", "2"}, 2)
+	if len(handler.sets["set"]) != 1 || slices.Contains(handler.sets["set"], popped[0]) {
+		t.Errorf("%v are not removed from %v", popped, handler.sets["set"])
+	}
+
+	remain := handler.sets["set"][0]
+
+	res, err = server.executeCommand(conn, "SMOVE", NewStringArguments("set", "other", remain))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if n, _ := res.Integer(); n != 1 || len(handler.sets["set"]) != 0 || !slices.Equal(handler.sets["other"], []string{remain}) {
+		t.Errorf("%d %v", n, handler.sets)
+	}
+
+	res, err = server.executeCommand(conn, "SPOP", NewStringArguments("set"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !res.IsNil() {
+		t.Errorf("%v is not nil", res)
+	}
+
+	if n := server.keyLock.Len(); n != 0 {
+		t.Errorf("%d != %d", n, 0)
+	}
+}
+
+func TestSugarListCommands(t *testing.T) {
+	handler := &testListCommandHandler{
+		testCommandHandler: testCommandHandler{UserCommandHandler: nil},
+		mutex:              &sync.Mutex{},
+		lists:              map[string][]string{"list": {"a", "b", "c", "a", "b", "a"}},
+	}
+
+	server, conn := testSugarServer(t, handler)
+
+	execute := func(cmd string, args ...string) *Message {
+		t.Helper()
+
+		res, err := server.executeCommand(conn, cmd, NewStringArguments(args...))
+		if err != nil {
+			t.Fatalf("%s %v: %s", cmd, args, err)
+		}
+
+		return res
+	}
+
+	posTests := []struct {
+		args     []string
+		expected []int
+	}{
+		{[]string{"list", "a", "COUNT", "0"}, []int{0, 3, 5}},
+		{[]string{"list", "a", "RANK", "2", "COUNT", "0"}, []int{3, 5}},
+		{[]string{"list", "a", "RANK", "-1", "COUNT", "2"}, []int{5, 3}},
+		{[]string{"list", "a", "COUNT", "0", "MAXLEN", "4"}, []int{0, 3}},
+		{[]string{"list", "x", "COUNT", "0"}, []int{}},
+	}
+
+	for _, test := range posTests {
+		array, err := execute("LPOS", test.args...).Array()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		idxs := []int{}
+		for n, err := array.NextInteger(); err == nil; n, err = array.NextInteger() {
+			idxs = append(idxs, n)
+		}
+
+		if !slices.Equal(idxs, test.expected) {
+			t.Errorf("LPOS %v: %v != %v", test.args, idxs, test.expected)
+		}
+	}
+
+	if n, _ := execute("LPOS", "list", "b").Integer(); n != 1 {
+		t.Errorf("%d != %d", n, 1)
+	}
+
+	if res := execute("LPOS", "list", "x"); !res.IsNil() {
+		t.Errorf("%v is not nil", res)
+	}
+
+	if elem, _ := execute("LMOVE", "list", "other", "LEFT", "RIGHT").String(); elem != "a" {
+		t.Errorf("%s != %s", elem, "a")
+	}
+
+	if elem, _ := execute("RPOPLPUSH", "list", "other").String(); elem != "a" {
+		t.Errorf("%s != %s", elem, "a")
+	}
+
+	if !slices.Equal(handler.lists["other"], []string{"a", "a"}) || !slices.Equal(handler.lists["list"], []string{"b", "c", "a", "b"}) {
+		t.Errorf("%v", handler.lists)
+	}
+
+	array, err := execute("LMPOP", "2", "empty", "list", "RIGHT", "COUNT", "3").Array()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, _ := array.NextString()
+	elemsMsg, _ := array.Next()
+
+	elems, err := messageStrings(elemsMsg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if key != "list" || !slices.Equal(elems, []string{"b", "a", "c"}) {
+		t.Errorf("%s %v", key, elems)
+	}
+
+	if res := execute("LMPOP", "1", "empty", "LEFT"); !res.IsNil() {
+		t.Errorf("%v is not nil", res)
+	}
+
+	// Huge numkeys are rejected before allocating the keys.
+	if _, err := server.executeCommand(conn, "LMPOP", NewStringArguments("9223372036854775807", "list", "LEFT")); !errors.Is(err, ErrNumKeysExceedArgs) {
+		t.Errorf("LMPOP: %v", err)
+	}
+
+	if n := server.keyLock.Len(); n != 0 {
+		t.Errorf("%d != %d", n, 0)
+	}
+}
@@ -14,6 +14,12 @@
 
 package redis
 
+import (
+	"strings"
+
+	"github.com/cybergarage/go-redis/redis/glob"
+)
+
 func (server *server) Ping(conn *Conn, arg string) (*Message, error) {
 	if len(arg) == 0 {
 		return NewStringMessage("PONG"), nil
@@ -36,8 +42,29 @@ func (server *server) Quit(conn *Conn) (*Message, error) {
 }
 
 func (server *server) ConfigSet(conn *Conn, params map[string]string) (*Message, error) {
+	// Changes no parameters if any of them is invalid as Redis.
+	for key, param := range params {
+		err := validateConfig(key, param)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	isTLSFileChanged := false
+
 	for key, param := range params {
 		server.SetConfig(key, param)
+
+		if isTLSFileConfig(key) {
+			isTLSFileChanged = true
+		}
+	}
+
+	if isTLSFileChanged {
+		err := server.ReloadTLS()
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return NewOKMessage(), nil
@@ -46,6 +73,22 @@ func (server *server) ConfigSet(conn *Conn, params map[string]string) (*Message,
 func (server *server) ConfigGet(conn *Conn, keys []string) (*Message, error) {
 	msg := NewArrayMessage()
 	for _, key := range keys {
+		// Returns all matched parameters for glob-style patterns as Redis.
+		if strings.ContainsAny(key, "*?[\\") {
+			pattern := glob.MustCompile(key, glob.WithNoCase())
+			for _, cfgKey := range server.ConfigKeys() {
+				if !pattern.MatchString(cfgKey) {
+					continue
+				}
+
+				param, _ := server.ConfigString(cfgKey)
+				msg.Append(NewBulkMessage(cfgKey))
+				msg.Append(NewBulkMessage(param))
+			}
+
+			continue
+		}
+
 		msg.Append(NewBulkMessage(key))
 
 		param, ok := server.ConfigString(key)
@@ -15,6 +15,7 @@
 package redistest
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"slices"
@@ -447,6 +448,145 @@ func GenericCommandTest(t *testing.T, client *Client) {
 			})
 		}
 	})
+
+	t.Run("COPY", func(t *testing.T) {
+		err := client.HSet("mykey_copy", "field", "Hello").Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		records := []struct {
+			args     []any
+			expected int64
+		}{
+			{[]any{"COPY", "mykey_copy", "myotherkey_copy"}, 1},
+			{[]any{"COPY", "mykey_copy", "myotherkey_copy"}, 0},
+			{[]any{"COPY", "mykey_copy", "myotherkey_copy", "REPLACE"}, 1},
+			{[]any{"COPY", "mykey_copy", "mykey_copy", "DB", 2}, 1},
+		}
+		for _, r := range records {
+			res, err := client.Do(r.args...).Int64()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if res != r.expected {
+				t.Errorf("%v: %d != %d", r.args, res, r.expected)
+				return
+			}
+		}
+
+		// The copy is independent of the source.
+		err = client.HSet("mykey_copy", "field", "World").Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		val, err := client.HGet("myotherkey_copy", "field").Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if val != "Hello" {
+			t.Errorf("%s != %s", val, "Hello")
+		}
+
+		if err := client.Do("COPY", "mykey_copy", "mykey_copy").Err(); err == nil {
+			t.Errorf("COPY to the same key should fail")
+		}
+	})
+
+	t.Run("MOVE", func(t *testing.T) {
+		key := "mykey_move"
+
+		err := client.Set(key, "Hello", 0).Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		records := []struct {
+			db       int64
+			expected bool
+		}{
+			{2, true},
+			{2, false},
+		}
+		for _, r := range records {
+			res, err := client.Move(key, r.db).Result()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if res != r.expected {
+				t.Errorf("%t != %t", res, r.expected)
+				return
+			}
+		}
+
+		if n, err := client.Exists(key).Result(); err != nil || n != 0 {
+			t.Errorf("%d (%v) != %d", n, err, 0)
+		}
+	})
+
+	t.Run("TOUCH", func(t *testing.T) {
+		err := client.MSet("key1_touch", "Hello", "key2_touch", "World").Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		res, err := client.Touch("key1_touch", "key2_touch", "key3_touch").Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if res != 2 {
+			t.Errorf("%d != %d", res, 2)
+		}
+	})
+
+	t.Run("UNLINK", func(t *testing.T) {
+		err := client.MSet("key1_unlink", "Hello", "key2_unlink", "World").Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		res, err := client.Unlink("key1_unlink", "key2_unlink", "key3_unlink").Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if res != 2 {
+			t.Errorf("%d != %d", res, 2)
+		}
+	})
+
+	t.Run("RANDOMKEY", func(t *testing.T) {
+		err := client.Set("mykey_randomkey", "Hello", 0).Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		key, err := client.RandomKey().Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if n, err := client.Exists(key).Result(); err != nil || n != 1 {
+			t.Errorf("%s: %d (%v) != %d", key, n, err, 1)
+		}
+	})
 }
 
 // GenericTTLCommandTest runs TTL-related generic command tests.
@@ -558,6 +698,182 @@ func GenericTTLCommandTest(t *testing.T, client *Client) {
 			})
 		}
 	})
+
+	t.Run("PERSIST", func(t *testing.T) {
+		key := "mykey_persist"
+
+		err := client.Set(key, "Hello", 0).Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		err = client.Expire(key, time.Hour).Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		records := []bool{true, false}
+		for _, expected := range records {
+			res, err := client.Persist(key).Result()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if res != expected {
+				t.Errorf("%t != %t", res, expected)
+				return
+			}
+		}
+
+		ttl, err := client.TTL(key).Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if ttl != -time.Second {
+			t.Errorf("%v != %v", ttl, -time.Second)
+		}
+	})
+
+	t.Run("PEXPIRE", func(t *testing.T) {
+		key := "mykey_pexpire"
+
+		records := []struct {
+			args     []any
+			expected int64
+		}{
+			{[]any{"PTTL", key}, -2},
+			{[]any{"EXPIRETIME", key}, -2},
+			{[]any{"SET", key, "Hello"}, -1},
+			{[]any{"PTTL", key}, -1},
+			{[]any{"PEXPIRETIME", key}, -1},
+			{[]any{"PEXPIRE", key, 5000, "XX"}, 0},
+			{[]any{"PEXPIRE", key, 5000, "NX"}, 1},
+			{[]any{"PEXPIRE", key, 4000, "GT"}, 0},
+			{[]any{"PEXPIRE", key, 6000, "GT"}, 1},
+			{[]any{"PEXPIRE", key, 7000, "LT"}, 0},
+		}
+		for _, r := range records {
+			if r.args[0] == "SET" {
+				if err := client.Do(r.args...).Err(); err != nil {
+					t.Error(err)
+					return
+				}
+
+				continue
+			}
+
+			res, err := client.Do(r.args...).Int64()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if res != r.expected {
+				t.Errorf("%v: %d != %d", r.args, res, r.expected)
+				return
+			}
+		}
+
+		pttl, err := client.PTTL(key).Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if pttl <= 5*time.Second || 6*time.Second < pttl {
+			t.Errorf("%v is out of range", pttl)
+		}
+
+		expireAt := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+
+		err = client.PExpireAt(key, expireAt).Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		res, err := client.Do("PEXPIRETIME", key).Int64()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if res != expireAt.UnixMilli() {
+			t.Errorf("%d != %d", res, expireAt.UnixMilli())
+		}
+
+		res, err = client.Do("EXPIRETIME", key).Int64()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if res != expireAt.Unix() {
+			t.Errorf("%d != %d", res, expireAt.Unix())
+		}
+	})
+
+	t.Run("PSETEX", func(t *testing.T) {
+		key := "mykey_psetex"
+
+		err := client.Do("PSETEX", key, 10000, "Hello").Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		val, err := client.Get(key).Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if val != "Hello" {
+			t.Errorf("%s != %s", val, "Hello")
+		}
+
+		ttl, err := client.TTL(key).Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if ttl != 10*time.Second {
+			t.Errorf("%v != %v", ttl, 10*time.Second)
+		}
+	})
+
+	t.Run("EXPIRED", func(t *testing.T) {
+		key := "mykey_expired"
+
+		err := client.Set(key, "Hello", 100*time.Millisecond).Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		time.Sleep(200 * time.Millisecond)
+
+		err = client.Get(key).Err()
+		if !errors.Is(err, goredis.Nil) {
+			t.Errorf("%v != %v", err, goredis.Nil)
+		}
+
+		n, err := client.Exists(key).Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if n != 0 {
+			t.Errorf("%d != %d", n, 0)
+		}
+	})
 }
 
 // StringCommandTest runs string command tests.
@@ -1312,6 +1628,27 @@ func HashCommandTest(t *testing.T, client *Client) {
 			})
 		}
 	})
+
+	t.Run("HSCAN", func(t *testing.T) {
+		key := "myhash_hscan"
+
+		err := client.HMSet(key, map[string]interface{}{"field1": "Hello", "field2": "World", "name": "Redis"}).Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		res, cursor, err := client.HScan(key, 0, "field*", 10).Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if expected := []string{"field1", "Hello", "field2", "World"}; !isStringsEqual(res, expected) || cursor != 0 {
+			t.Errorf("%v (%d) != %v (%d)", res, cursor, expected, 0)
+			return
+		}
+	})
 }
 
 // ListCommandTest runs list command tests.
@@ -1668,33 +2005,345 @@ func ListCommandTest(t *testing.T, client *Client) {
 			})
 		}
 	})
-}
 
-// SetCommandTest runs set command tests.
-//
-//nolint:maintidx,gocyclo,dupl
-func SetCommandTest(t *testing.T, client *Client) {
-	t.Helper()
+	t.Run("LSET", func(t *testing.T) {
+		key := "mylist_lset"
 
-	t.Run("SADD", func(t *testing.T) {
-		key := "myset_sadd"
-		records := []struct {
-			mems         []string
-			expectedRet  int64
-			expectedMems []string
-		}{
-			{[]string{"Hello"}, 1, []string{"Hello"}},
-			{[]string{"World"}, 1, []string{"Hello", "World"}},
-			{[]string{"World"}, 0, []string{"Hello", "World"}},
+		err := client.RPush(key, "one", "two", "three").Err()
+		if err != nil {
+			t.Error(err)
+			return
 		}
 
-		for _, r := range records {
-			t.Run(strings.Join(r.mems, ","), func(t *testing.T) {
-				res, err := client.SAdd(key, r.mems).Result()
-				if err != nil {
-					t.Error(err)
-					return
-				}
+		err = client.LSet(key, 0, "four").Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		err = client.LSet(key, -2, "five").Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err := client.LSet(key, 3, "six").Err(); err == nil {
+			t.Errorf("LSET out of range should fail")
+		}
+
+		if err := client.LSet("mylist_lset_none", 0, "six").Err(); err == nil {
+			t.Errorf("LSET of no key should fail")
+		}
+
+		n, err := client.LInsert(key, "BEFORE", "five", "six").Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if n != 4 {
+			t.Errorf("%d != %d", n, 4)
+		}
+
+		n, err = client.LInsertAfter(key, "none", "seven").Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if n != -1 {
+			t.Errorf("%d != %d", n, -1)
+		}
+
+		rng, err := client.LRange(key, 0, -1).Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if expected := []string{"four", "six", "five", "three"}; !reflect.DeepEqual(rng, expected) {
+			t.Errorf("%v != %v", rng, expected)
+		}
+	})
+
+	t.Run("LREM", func(t *testing.T) {
+		key := "mylist_lrem"
+
+		err := client.RPush(key, "hello", "hello", "foo", "hello", "hello").Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		records := []struct {
+			count       int64
+			expectedRet int64
+			expectedRng []string
+		}{
+			{-1, 1, []string{"hello", "hello", "foo", "hello"}},
+			{1, 1, []string{"hello", "foo", "hello"}},
+			{0, 2, []string{"foo"}},
+		}
+		for _, r := range records {
+			n, err := client.LRem(key, r.count, "hello").Result()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if n != r.expectedRet {
+				t.Errorf("%d != %d", n, r.expectedRet)
+			}
+
+			rng, err := client.LRange(key, 0, -1).Result()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if !reflect.DeepEqual(rng, r.expectedRng) {
+				t.Errorf("%v != %v", rng, r.expectedRng)
+			}
+		}
+	})
+
+	t.Run("LTRIM", func(t *testing.T) {
+		key := "mylist_ltrim"
+
+		err := client.RPush(key, "one", "two", "three").Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		err = client.LTrim(key, 1, -1).Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		rng, err := client.LRange(key, 0, -1).Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if expected := []string{"two", "three"}; !reflect.DeepEqual(rng, expected) {
+			t.Errorf("%v != %v", rng, expected)
+		}
+	})
+
+	t.Run("LPOS", func(t *testing.T) {
+		key := "mylist_lpos"
+
+		err := client.RPush(key, "a", "b", "c", "1", "2", "3", "c", "c").Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		records := []struct {
+			args     []any
+			expected any
+		}{
+			{[]any{"c"}, int64(2)},
+			{[]any{"c", "RANK", "2"}, int64(6)},
+			{[]any{"c", "RANK", "-1"}, int64(7)},
+			{[]any{"c", "COUNT", "2"}, []any{int64(2), int64(6)}},
+			{[]any{"c", "RANK", "-1", "COUNT", "2"}, []any{int64(7), int64(6)}},
+			{[]any{"c", "COUNT", "0", "MAXLEN", "7"}, []any{int64(2), int64(6)}},
+		}
+		for _, r := range records {
+			res, err := client.Do(append([]any{"LPOS", key}, r.args...)...).Result()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if !reflect.DeepEqual(res, r.expected) {
+				t.Errorf("%v: %v != %v", r.args, res, r.expected)
+			}
+		}
+
+		err = client.Do("LPOS", key, "x").Err()
+		if !errors.Is(err, goredis.Nil) {
+			t.Errorf("%v != %v", err, goredis.Nil)
+		}
+	})
+
+	t.Run("LMOVE", func(t *testing.T) {
+		src := "mylist_lmove"
+		dst := "myotherlist_lmove"
+
+		err := client.RPush(src, "one", "two", "three").Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		res, err := client.Do("LMOVE", src, dst, "RIGHT", "LEFT").Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if res != "three" {
+			t.Errorf("%v != %s", res, "three")
+		}
+
+		elem, err := client.RPopLPush(src, dst).Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if elem != "two" {
+			t.Errorf("%s != %s", elem, "two")
+		}
+
+		rng, err := client.LRange(dst, 0, -1).Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if expected := []string{"two", "three"}; !reflect.DeepEqual(rng, expected) {
+			t.Errorf("%v != %v", rng, expected)
+		}
+
+		res, err = client.Do("LMPOP", "2", "mylist_lmpop_none", dst, "LEFT", "COUNT", "5").Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if expected := []any{dst, []any{"two", "three"}}; !reflect.DeepEqual(res, expected) {
+			t.Errorf("%v != %v", res, expected)
+		}
+
+		err = client.Do("LMPOP", "1", dst, "LEFT").Err()
+		if !errors.Is(err, goredis.Nil) {
+			t.Errorf("%v != %v", err, goredis.Nil)
+		}
+	})
+
+	t.Run("SORT", func(t *testing.T) {
+		key := "mylist_sort"
+
+		err := client.RPush(key, "3", "1", "2", "10").Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		err = client.MSet("weight_sort_1", "30", "weight_sort_2", "20", "weight_sort_3", "10").Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		err = client.HMSet("object_sort_1", map[string]interface{}{"name": "one"}).Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		err = client.HMSet("object_sort_2", map[string]interface{}{"name": "two"}).Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		records := []struct {
+			sort     *goredis.Sort
+			expected []string
+		}{
+			{&goredis.Sort{}, []string{"1", "2", "3", "10"}},
+			{&goredis.Sort{Order: "DESC"}, []string{"10", "3", "2", "1"}},
+			{&goredis.Sort{Alpha: true}, []string{"1", "10", "2", "3"}},
+			{&goredis.Sort{Offset: 1, Count: 2}, []string{"2", "3"}},
+			{&goredis.Sort{By: "nosort"}, []string{"3", "1", "2", "10"}},
+			// The missing weight of 10 is 0.
+			{&goredis.Sort{By: "weight_sort_*"}, []string{"10", "3", "2", "1"}},
+			{&goredis.Sort{By: "weight_sort_*", Get: []string{"#", "object_sort_*->name"}, Offset: 2, Count: 2}, []string{"2", "two", "1", "one"}},
+		}
+		for _, r := range records {
+			res, err := client.Sort(key, r.sort).Result()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if !reflect.DeepEqual(res, r.expected) {
+				t.Errorf("%v: %v != %v", r.sort, res, r.expected)
+			}
+		}
+
+		res, err := client.Do("SORT_RO", key, "DESC").Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if expected := []any{"10", "3", "2", "1"}; !reflect.DeepEqual(res, expected) {
+			t.Errorf("%v != %v", res, expected)
+		}
+
+		if err := client.Do("SORT_RO", key, "STORE", "mylist_sort_ro").Err(); err == nil {
+			t.Errorf("SORT_RO should not accept STORE")
+		}
+
+		n, err := client.SortStore(key, "mylist_sort_store", &goredis.Sort{Order: "DESC"}).Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if n != 4 {
+			t.Errorf("%d != %d", n, 4)
+		}
+
+		stored, err := client.LRange("mylist_sort_store", 0, -1).Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if expected := []string{"10", "3", "2", "1"}; !reflect.DeepEqual(stored, expected) {
+			t.Errorf("%v != %v", stored, expected)
+		}
+
+		if err := client.Sort("weight_sort_1", &goredis.Sort{}).Err(); err == nil {
+			t.Errorf("SORT of a string key should fail")
+		}
+	})
+}
+
+// SetCommandTest runs set command tests.
+//
+//nolint:maintidx,gocyclo,dupl
+func SetCommandTest(t *testing.T, client *Client) {
+	t.Helper()
+
+	t.Run("SADD", func(t *testing.T) {
+		key := "myset_sadd"
+		records := []struct {
+			mems         []string
+			expectedRet  int64
+			expectedMems []string
+		}{
+			{[]string{"Hello"}, 1, []string{"Hello"}},
+			{[]string{"World"}, 1, []string{"Hello", "World"}},
+			{[]string{"World"}, 0, []string{"Hello", "World"}},
+		}
+
+		for _, r := range records {
+			t.Run(strings.Join(r.mems, ","), func(t *testing.T) {
+				res, err := client.SAdd(key, r.mems).Result()
+				if err != nil {
+					t.Error(err)
+					return
+				}
 
 				if res != r.expectedRet {
 					t.Errorf("%d != %d", r.expectedRet, res)
@@ -1833,6 +2482,219 @@ func SetCommandTest(t *testing.T, client *Client) {
 			})
 		}
 	})
+
+	t.Run("SSCAN", func(t *testing.T) {
+		key := "myset_sscan"
+
+		err := client.SAdd(key, "one", "two", "three").Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		members := []string{}
+		cursor := uint64(0)
+
+		for {
+			res, nextCursor, err := client.SScan(key, cursor, "*", 1).Result()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			members = append(members, res...)
+
+			if nextCursor == 0 {
+				break
+			}
+
+			cursor = nextCursor
+		}
+
+		if expected := []string{"one", "three", "two"}; !isStringsEqual(members, expected) {
+			t.Errorf("%v != %v", members, expected)
+		}
+	})
+
+	t.Run("SINTER", func(t *testing.T) {
+		keys := []string{"key1_salgebra", "key2_salgebra", "key3_salgebra"}
+
+		for n, members := range [][]any{{"a", "b", "c", "d"}, {"c"}, {"a", "c", "e"}} {
+			err := client.SAdd(keys[n], members...).Err()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+		}
+
+		records := []struct {
+			cmd      func(keys ...string) *goredis.StringSliceCmd
+			expected []string
+		}{
+			{client.SInter, []string{"c"}},
+			{client.SUnion, []string{"a", "b", "c", "d", "e"}},
+			{client.SDiff, []string{"b", "d"}},
+		}
+		for _, r := range records {
+			res, err := r.cmd(keys...).Result()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if !isStringsEqual(res, r.expected) {
+				t.Errorf("%v != %v", res, r.expected)
+			}
+		}
+
+		storeRecords := []struct {
+			cmd      func(dst string, keys ...string) *goredis.IntCmd
+			expected []string
+		}{
+			{client.SInterStore, []string{"c"}},
+			{client.SUnionStore, []string{"a", "b", "c", "d", "e"}},
+			{client.SDiffStore, []string{"b", "d"}},
+		}
+		for _, r := range storeRecords {
+			dst := "dst_salgebra"
+
+			n, err := r.cmd(dst, keys...).Result()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if n != int64(len(r.expected)) {
+				t.Errorf("%d != %d", n, len(r.expected))
+			}
+
+			res, err := client.SMembers(dst).Result()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if !isStringsEqual(res, r.expected) {
+				t.Errorf("%v != %v", res, r.expected)
+			}
+		}
+
+		cardRecords := []struct {
+			args     []any
+			expected int64
+		}{
+			{[]any{"SINTERCARD", 2, keys[0], keys[2]}, 2},
+			{[]any{"SINTERCARD", 2, keys[0], keys[2], "LIMIT", 1}, 1},
+			{[]any{"SINTERCARD", 2, keys[0], "nokey_salgebra"}, 0},
+		}
+		for _, r := range cardRecords {
+			n, err := client.Do(r.args...).Int64()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if n != r.expected {
+				t.Errorf("%v: %d != %d", r.args, n, r.expected)
+			}
+		}
+	})
+
+	t.Run("SPOP", func(t *testing.T) {
+		key := "myset_spop"
+
+		err := client.SAdd(key, "one", "two", "three").Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		members, err := client.SRandMemberN(key, -5).Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if len(members) != 5 {
+			t.Errorf("%v", members)
+		}
+
+		members, err = client.SPopN(key, 2).Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if len(members) != 2 {
+			t.Errorf("%v", members)
+		}
+
+		n, err := client.SCard(key).Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if n != 1 {
+			t.Errorf("%d != %d", n, 1)
+		}
+
+		member, err := client.SPop(key).Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if slices.Contains(members, member) {
+			t.Errorf("%s is popped twice", member)
+		}
+
+		err = client.SPop(key).Err()
+		if !errors.Is(err, goredis.Nil) {
+			t.Errorf("%v != %v", err, goredis.Nil)
+		}
+	})
+
+	t.Run("SMOVE", func(t *testing.T) {
+		src := "myset_smove"
+		dst := "myotherset_smove"
+
+		err := client.SAdd(src, "one", "two").Err()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		records := []struct {
+			member   string
+			expected bool
+		}{
+			{"two", true},
+			{"two", false},
+			{"three", false},
+		}
+		for _, r := range records {
+			res, err := client.SMove(src, dst, r.member).Result()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if res != r.expected {
+				t.Errorf("%s: %t != %t", r.member, res, r.expected)
+			}
+		}
+
+		res, err := client.Do("SMISMEMBER", dst, "one", "two").Result()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		if expected := []any{int64(0), int64(1)}; !reflect.DeepEqual(res, expected) {
+			t.Errorf("%v != %v", res, expected)
+		}
+	})
 }
 
 // ZSetCommandTest runs sorted set (zset) command tests.
@@ -16,6 +16,7 @@ package redistest
 
 import (
 	"testing"
+	"time"
 
 	"github.com/cybergarage/go-redis/redis"
 	"github.com/cybergarage/go-redis/redis/auth"
@@ -162,3 +163,35 @@ func TestTLSServer(t *testing.T) {
 		return
 	}
 }
+
+func TestShutdownCommand(t *testing.T) {
+	const testPort = 6382
+
+	server := NewServer()
+	server.SetPort(testPort)
+
+	err := server.Start()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	client := NewClient()
+	clientOpts := NewClientOptions()
+
+	err = client.OpenWith(LocalHost, testPort, &clientOpts)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	defer client.Close()
+
+	client.Shutdown()
+
+	select {
+	case <-server.Done():
+	case <-time.After(5 * time.Second):
+		t.Errorf("server is not shut down")
+	}
+}